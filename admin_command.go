@@ -24,25 +24,32 @@ import (
 
 const (
 	// Commands
-	_AUTHENTICATE    byte = 0
-	_CREATE_USER     byte = 1
-	_DROP_USER       byte = 2
-	_SET_PASSWORD    byte = 3
-	_CHANGE_PASSWORD byte = 4
-	_GRANT_ROLES     byte = 5
-	_REVOKE_ROLES    byte = 6
-	_REPLACE_ROLES   byte = 7
-	//_CREATE_ROLE byte = 8;
-	_QUERY_USERS byte = 9
-	//_QUERY_ROLES byte =  10;
+	_AUTHENTICATE      byte = 0
+	_CREATE_USER       byte = 1
+	_DROP_USER         byte = 2
+	_SET_PASSWORD      byte = 3
+	_CHANGE_PASSWORD   byte = 4
+	_GRANT_ROLES       byte = 5
+	_REVOKE_ROLES      byte = 6
+	_REPLACE_ROLES     byte = 7
+	_QUERY_USERS       byte = 9
+	_CREATE_ROLE       byte = 10
+	_DROP_ROLE         byte = 11
+	_GRANT_PRIVILEGES  byte = 12
+	_REVOKE_PRIVILEGES byte = 13
+	_QUERY_ROLES       byte = 16
+	_LOGIN             byte = 20
 
 	// Field IDs
-	_USER         byte = 0
-	_PASSWORD     byte = 1
-	_OLD_PASSWORD byte = 2
-	_CREDENTIAL   byte = 3
-	_ROLES        byte = 10
-	//_PRIVILEGES byte =  11;
+	_USER          byte = 0
+	_PASSWORD      byte = 1
+	_OLD_PASSWORD  byte = 2
+	_CREDENTIAL    byte = 3
+	_SESSION_TOKEN byte = 5
+	_SESSION_TTL   byte = 6
+	_ROLES         byte = 10
+	_ROLE          byte = 11
+	_PRIVILEGES    byte = 12
 
 	// Misc
 	_MSG_VERSION int64 = 0
@@ -67,8 +74,18 @@ func newAdminCommand() *AdminCommand {
 }
 
 func (acmd *AdminCommand) authenticate(conn *Connection, user string, password []byte) error {
+	return acmd.authenticateWith(conn, user, _CREDENTIAL, password)
+}
+
+// authenticateSession authenticates the connection with a session token
+// returned by login.
+func (acmd *AdminCommand) authenticateSession(conn *Connection, user string, token []byte) error {
+	return acmd.authenticateWith(conn, user, _SESSION_TOKEN, token)
+}
 
-	acmd.setAuthenticate(user, password)
+func (acmd *AdminCommand) authenticateWith(conn *Connection, user string, credentialID byte, credential []byte) error {
+
+	acmd.setAuthenticate(user, credentialID, credential)
 	if _, err := conn.Write(acmd.dataBuffer[:acmd.dataOffset]); err != nil {
 		return err
 	}
@@ -87,15 +104,81 @@ func (acmd *AdminCommand) authenticate(conn *Connection, user string, password [
 	return nil
 }
 
-func (acmd *AdminCommand) setAuthenticate(user string, password []byte) int {
+func (acmd *AdminCommand) setAuthenticate(user string, credentialID byte, credential []byte) int {
 	acmd.writeHeader(_AUTHENTICATE, 2)
 	acmd.writeFieldStr(_USER, user)
-	acmd.writeFieldBytes(_CREDENTIAL, password)
+	acmd.writeFieldBytes(credentialID, credential)
 	acmd.writeSize()
 
 	return acmd.dataOffset
 }
 
+// login authenticates the connection with the user's password, and returns
+// the session token for authenticating new connections, and its expiration.
+// A zero expiration means the session does not expire.
+func (acmd *AdminCommand) login(conn *Connection, user string, password []byte) (token []byte, expiration time.Time, err error) {
+	defer func() { bufPool.Put(acmd.dataBuffer) }()
+
+	acmd.writeHeader(_LOGIN, 2)
+	acmd.writeFieldStr(_USER, user)
+	acmd.writeFieldBytes(_CREDENTIAL, password)
+	acmd.writeSize()
+
+	if _, err := conn.Write(acmd.dataBuffer[:acmd.dataOffset]); err != nil {
+		return nil, expiration, err
+	}
+
+	if _, err := conn.Read(acmd.dataBuffer, _HEADER_SIZE); err != nil {
+		return nil, expiration, err
+	}
+
+	result := acmd.dataBuffer[_RESULT_CODE]
+	if result != 0 {
+		return nil, expiration, NewAerospikeError(ResultCode(result), "Login failed")
+	}
+
+	receiveSize := int(Buffer.BytesToInt64(acmd.dataBuffer, 0)&0xFFFFFFFFFFFF) - _HEADER_REMAINING
+	fieldCount := int(acmd.dataBuffer[_HEADER_SIZE-_HEADER_REMAINING+3])
+	if receiveSize <= 0 || fieldCount <= 0 {
+		return nil, expiration, NewAerospikeError(PARSE_ERROR, "Login response does not contain a session token")
+	}
+
+	if receiveSize > len(acmd.dataBuffer) {
+		acmd.dataBuffer = make([]byte, receiveSize)
+	}
+	if _, err := conn.Read(acmd.dataBuffer, receiveSize); err != nil {
+		return nil, expiration, err
+	}
+
+	acmd.dataOffset = 0
+	for i := 0; i < fieldCount; i++ {
+		len := int(Buffer.BytesToInt32(acmd.dataBuffer, acmd.dataOffset))
+		acmd.dataOffset += 4
+		id := acmd.dataBuffer[acmd.dataOffset]
+		acmd.dataOffset++
+		len--
+
+		switch id {
+		case _SESSION_TOKEN:
+			token = make([]byte, len)
+			copy(token, acmd.dataBuffer[acmd.dataOffset:acmd.dataOffset+len])
+		case _SESSION_TTL:
+			// expire the session on the client a minute before the server does,
+			// so that it is refreshed before new connections are rejected
+			ttl := int64(uint32(Buffer.BytesToInt32(acmd.dataBuffer, acmd.dataOffset))) - 60
+			if ttl > 0 {
+				expiration = time.Now().Add(time.Duration(ttl) * time.Second)
+			}
+		}
+		acmd.dataOffset += len
+	}
+
+	if token == nil {
+		return nil, expiration, NewAerospikeError(PARSE_ERROR, "Login response does not contain a session token")
+	}
+	return token, expiration, nil
+}
+
 func (acmd *AdminCommand) createUser(cluster *Cluster, policy *AdminPolicy, user string, password []byte, roles []string) error {
 	acmd.writeHeader(_CREATE_USER, 3)
 	acmd.writeFieldStr(_USER, user)
@@ -120,7 +203,8 @@ func (acmd *AdminCommand) setPassword(cluster *Cluster, policy *AdminPolicy, use
 func (acmd *AdminCommand) changePassword(cluster *Cluster, policy *AdminPolicy, user string, password []byte) error {
 	acmd.writeHeader(_CHANGE_PASSWORD, 3)
 	acmd.writeFieldStr(_USER, user)
-	acmd.writeFieldBytes(_OLD_PASSWORD, cluster.password)
+	_, oldPassword := cluster.credentials()
+	acmd.writeFieldBytes(_OLD_PASSWORD, oldPassword)
 	acmd.writeFieldBytes(_PASSWORD, password)
 	return acmd.executeCommand(cluster, policy)
 }
@@ -178,6 +262,74 @@ func (acmd *AdminCommand) queryUsers(cluster *Cluster, policy *AdminPolicy) ([]*
 	return list, nil
 }
 
+func (acmd *AdminCommand) createRole(cluster *Cluster, policy *AdminPolicy, roleName string, privileges []Privilege) error {
+	acmd.writeHeader(_CREATE_ROLE, 2)
+	acmd.writeFieldStr(_ROLE, roleName)
+	if err := acmd.writePrivileges(privileges); err != nil {
+		bufPool.Put(acmd.dataBuffer)
+		return err
+	}
+	return acmd.executeCommand(cluster, policy)
+}
+
+func (acmd *AdminCommand) dropRole(cluster *Cluster, policy *AdminPolicy, roleName string) error {
+	acmd.writeHeader(_DROP_ROLE, 1)
+	acmd.writeFieldStr(_ROLE, roleName)
+	return acmd.executeCommand(cluster, policy)
+}
+
+func (acmd *AdminCommand) grantPrivileges(cluster *Cluster, policy *AdminPolicy, roleName string, privileges []Privilege) error {
+	acmd.writeHeader(_GRANT_PRIVILEGES, 2)
+	acmd.writeFieldStr(_ROLE, roleName)
+	if err := acmd.writePrivileges(privileges); err != nil {
+		bufPool.Put(acmd.dataBuffer)
+		return err
+	}
+	return acmd.executeCommand(cluster, policy)
+}
+
+func (acmd *AdminCommand) revokePrivileges(cluster *Cluster, policy *AdminPolicy, roleName string, privileges []Privilege) error {
+	acmd.writeHeader(_REVOKE_PRIVILEGES, 2)
+	acmd.writeFieldStr(_ROLE, roleName)
+	if err := acmd.writePrivileges(privileges); err != nil {
+		bufPool.Put(acmd.dataBuffer)
+		return err
+	}
+	return acmd.executeCommand(cluster, policy)
+}
+
+func (acmd *AdminCommand) queryRole(cluster *Cluster, policy *AdminPolicy, roleName string) (*RolePrivileges, error) {
+	// TODO: Remove the workaround in the future
+	time.Sleep(time.Millisecond * 10)
+	defer bufPool.Put(acmd.dataBuffer)
+
+	acmd.writeHeader(_QUERY_ROLES, 1)
+	acmd.writeFieldStr(_ROLE, roleName)
+	list, err := acmd.readRoles(cluster, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(list) > 0 {
+		return list[0], nil
+	}
+
+	return nil, nil
+}
+
+func (acmd *AdminCommand) queryRoles(cluster *Cluster, policy *AdminPolicy) ([]*RolePrivileges, error) {
+	// TODO: Remove the workaround in the future
+	time.Sleep(time.Millisecond * 10)
+	defer bufPool.Put(acmd.dataBuffer)
+
+	acmd.writeHeader(_QUERY_ROLES, 0)
+	list, err := acmd.readRoles(cluster, policy)
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
 func (acmd *AdminCommand) writeRoles(roles []string) {
 	offset := acmd.dataOffset + int(_FIELD_HEADER_SIZE)
 	acmd.dataBuffer[offset] = byte(len(roles))
@@ -194,6 +346,45 @@ func (acmd *AdminCommand) writeRoles(roles []string) {
 	acmd.dataOffset = offset
 }
 
+// writePrivileges writes the privileges field. Each privilege is
+// its code, followed by the namespace and set names for scoped privileges.
+func (acmd *AdminCommand) writePrivileges(privileges []Privilege) error {
+	offset := acmd.dataOffset + int(_FIELD_HEADER_SIZE)
+	acmd.dataBuffer[offset] = byte(len(privileges))
+	offset++
+
+	for _, privilege := range privileges {
+		code, err := privilege.code()
+		if err != nil {
+			return err
+		}
+
+		acmd.dataBuffer[offset] = code
+		offset++
+
+		if canScope(code) {
+			if len(privilege.SetName) > 0 && len(privilege.Namespace) == 0 {
+				return NewAerospikeError(INVALID_PRIVILEGE, "Privilege "+string(privilege.Code)+" has a set scope with an empty namespace")
+			}
+
+			len := copy(acmd.dataBuffer[offset+1:], privilege.Namespace)
+			acmd.dataBuffer[offset] = byte(len)
+			offset += len + 1
+
+			len = copy(acmd.dataBuffer[offset+1:], privilege.SetName)
+			acmd.dataBuffer[offset] = byte(len)
+			offset += len + 1
+		} else if len(privilege.Namespace) > 0 || len(privilege.SetName) > 0 {
+			return NewAerospikeError(INVALID_PRIVILEGE, "Global privilege "+string(privilege.Code)+" can not have a namespace or set scope")
+		}
+	}
+
+	size := offset - acmd.dataOffset - int(_FIELD_HEADER_SIZE)
+	acmd.writeFieldHeader(_PRIVILEGES, size)
+	acmd.dataOffset = offset
+	return nil
+}
+
 func (acmd *AdminCommand) writeSize() {
 	// Write total size of message which is the current offset.
 	var size = int64(acmd.dataOffset-8) | (_MSG_VERSION << 56) | (_MSG_TYPE << 48)
@@ -271,10 +462,38 @@ func (acmd *AdminCommand) executeCommand(cluster *Cluster, policy *AdminPolicy)
 }
 
 func (acmd *AdminCommand) readUsers(cluster *Cluster, policy *AdminPolicy) ([]*UserRoles, error) {
+	var rlist []*UserRoles
+	err := acmd.readBlocks(cluster, policy, func(receiveSize int) (int, error) {
+		status, list, err := acmd.parseUsers(receiveSize)
+		rlist = append(rlist, list...)
+		return status, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rlist, nil
+}
+
+func (acmd *AdminCommand) readRoles(cluster *Cluster, policy *AdminPolicy) ([]*RolePrivileges, error) {
+	var rlist []*RolePrivileges
+	err := acmd.readBlocks(cluster, policy, func(receiveSize int) (int, error) {
+		status, list, err := acmd.parseRolePrivileges(receiveSize)
+		rlist = append(rlist, list...)
+		return status, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rlist, nil
+}
+
+// readBlocks sends the query command and parses the response blocks
+// with parseBlock until the end of the query.
+func (acmd *AdminCommand) readBlocks(cluster *Cluster, policy *AdminPolicy, parseBlock func(receiveSize int) (int, error)) error {
 	acmd.writeSize()
 	node, err := cluster.GetRandomNode()
 	if err != nil {
-		return nil, err
+		return err
 	}
 	timeout := 1 * time.Second
 	if policy != nil && policy.Timeout > 0 {
@@ -283,33 +502,30 @@ func (acmd *AdminCommand) readUsers(cluster *Cluster, policy *AdminPolicy) ([]*U
 
 	conn, err := node.GetConnection(timeout)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if _, err := conn.Write(acmd.dataBuffer[:acmd.dataOffset]); err != nil {
 		conn.Close()
-		return nil, err
+		return err
 	}
 
-	status, list, err := acmd.readUserBlocks(conn)
+	status, err := acmd.readResponseBlocks(conn, parseBlock)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	node.PutConnection(conn)
 
 	if status > 0 {
-		return nil, NewAerospikeError(ResultCode(status))
+		return NewAerospikeError(ResultCode(status))
 	}
-	return list, nil
+	return nil
 }
 
-func (acmd *AdminCommand) readUserBlocks(conn *Connection) (status int, rlist []*UserRoles, err error) {
-
-	var list []*UserRoles
-
+func (acmd *AdminCommand) readResponseBlocks(conn *Connection, parseBlock func(receiveSize int) (int, error)) (status int, err error) {
 	for status == 0 {
 		if _, err = conn.Read(acmd.dataBuffer, 8); err != nil {
-			return -1, nil, err
+			return -1, err
 		}
 
 		size := Buffer.BytesToInt64(acmd.dataBuffer, 0)
@@ -320,18 +536,17 @@ func (acmd *AdminCommand) readUserBlocks(conn *Connection) (status int, rlist []
 				acmd.dataBuffer = make([]byte, receiveSize)
 			}
 			if _, err = conn.Read(acmd.dataBuffer, int(receiveSize)); err != nil {
-				return -1, nil, err
+				return -1, err
 			}
-			status, list, err = acmd.parseUsers(int(receiveSize))
+			status, err = parseBlock(int(receiveSize))
 			if err != nil {
-				return -1, nil, err
+				return -1, err
 			}
-			rlist = append(rlist, list...)
 		} else {
 			break
 		}
 	}
-	return status, rlist, nil
+	return status, nil
 }
 
 func (acmd *AdminCommand) parseUsers(receiveSize int) (int, []*UserRoles, error) {
@@ -396,6 +611,79 @@ func (acmd *AdminCommand) parseRoles(userRoles *UserRoles) {
 	}
 }
 
+func (acmd *AdminCommand) parseRolePrivileges(receiveSize int) (int, []*RolePrivileges, error) {
+	acmd.dataOffset = 0
+	list := make([]*RolePrivileges, 0, 100)
+
+	for acmd.dataOffset < receiveSize {
+		resultCode := int(acmd.dataBuffer[acmd.dataOffset+1])
+
+		if resultCode != 0 {
+			if resultCode == _QUERY_END {
+				return -1, nil, nil
+			}
+			return resultCode, nil, nil
+		}
+
+		rolePrivileges := &RolePrivileges{}
+		fieldCount := int(acmd.dataBuffer[acmd.dataOffset+3])
+		acmd.dataOffset += _HEADER_REMAINING
+
+		for i := 0; i < fieldCount; i++ {
+			len := int(Buffer.BytesToInt32(acmd.dataBuffer, acmd.dataOffset))
+			acmd.dataOffset += 4
+			id := acmd.dataBuffer[acmd.dataOffset]
+			acmd.dataOffset++
+			len--
+
+			if id == _ROLE {
+				rolePrivileges.Role = string(acmd.dataBuffer[acmd.dataOffset : acmd.dataOffset+len])
+				acmd.dataOffset += len
+			} else if id == _PRIVILEGES {
+				acmd.parsePrivileges(rolePrivileges)
+			} else {
+				acmd.dataOffset += len
+			}
+		}
+
+		if rolePrivileges.Role == "" && rolePrivileges.Privileges == nil {
+			continue
+		}
+
+		if rolePrivileges.Privileges == nil {
+			rolePrivileges.Privileges = make([]Privilege, 0)
+		}
+		list = append(list, rolePrivileges)
+	}
+
+	return 0, list, nil
+}
+
+func (acmd *AdminCommand) parsePrivileges(rolePrivileges *RolePrivileges) {
+	size := int(acmd.dataBuffer[acmd.dataOffset])
+	acmd.dataOffset++
+	rolePrivileges.Privileges = make([]Privilege, 0, size)
+
+	for i := 0; i < size; i++ {
+		code := acmd.dataBuffer[acmd.dataOffset]
+		acmd.dataOffset++
+
+		privilege := Privilege{Code: privilegeRole(code)}
+		if canScope(code) {
+			len := int(acmd.dataBuffer[acmd.dataOffset])
+			acmd.dataOffset++
+			privilege.Namespace = string(acmd.dataBuffer[acmd.dataOffset : acmd.dataOffset+len])
+			acmd.dataOffset += len
+
+			len = int(acmd.dataBuffer[acmd.dataOffset])
+			acmd.dataOffset++
+			privilege.SetName = string(acmd.dataBuffer[acmd.dataOffset : acmd.dataOffset+len])
+			acmd.dataOffset += len
+		}
+		rolePrivileges.Privileges = append(rolePrivileges.Privileges, privilege)
+	}
+}
+
 func hashPassword(password string) ([]byte, error) {
 	// Hashing the password with the cost of 10, with a static salt
 	const salt = "$2a$10$7EqJtq98hPqEX7fNZaFWoO"
@@ -96,6 +96,33 @@ func Benchmark_GetObject(b *testing.B) {
 	benchGet(b.N, client, key, obj)
 }
 
+func Benchmark_GetWithHandler(b *testing.B) {
+	client, err := NewClientWithPolicy(clientPolicy, *host, *port)
+	if err != nil {
+		b.Fail()
+	}
+
+	key, _ := NewKey("test", "databases", "Aerospike")
+	obj := &OBJECT{198, "Jack Shaftoe and Company", []byte(bytes.Repeat([]byte{32}, 1000))}
+	client.PutObject(nil, key, obj)
+
+	size := 0
+	handler := func(binName string, particleType int, raw []byte) error {
+		size += len(raw)
+		return nil
+	}
+
+	b.N = 100000
+	runtime.GC()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err = client.GetWithHandler(nil, key, handler); err != nil {
+			panic(err)
+		}
+	}
+}
+
 func Benchmark_Put(b *testing.B) {
 	client, err := NewClient(*host, *port)
 	if err != nil {
@@ -116,6 +116,8 @@ func contains(a map[string]struct{}, elem string) bool {
 // Returns the number of bytes that were parsed from the given buffer.
 func (cmd *batchCommandGet) parseRecord(key *Key, opCount int, generation int, expiration int) (*Record, error) {
 	var bins map[string]interface{}
+	var raw map[string]rawBin
+	policy := cmd.policy.GetBasePolicy()
 
 	for i := 0; i < opCount; i++ {
 		if err := cmd.readBytes(8); err != nil {
@@ -134,23 +136,34 @@ func (cmd *batchCommandGet) parseRecord(key *Key, opCount int, generation int, e
 		if err := cmd.readBytes(particleBytesSize); err != nil {
 			return nil, err
 		}
-		value, err := bytesToParticle(particleType, cmd.dataBuffer, 0, particleBytesSize)
-		if err != nil {
-			return nil, err
-		}
 
 		// Currently, the batch command returns all the bins even if a subset of
 		// the bins are requested. We have to filter it on the client side.
 		// TODO: Filter batch bins on server!
-		if cmd.binNames == nil || contains(cmd.binNames, name) {
-			if bins == nil {
-				bins = map[string]interface{}{}
+		if cmd.binNames != nil && !contains(cmd.binNames, name) {
+			continue
+		}
+
+		if policy.LazyBins && isLazyParticle(particleType) {
+			if raw == nil {
+				raw = make(map[string]rawBin, opCount)
 			}
-			bins[name] = value
+			raw[name] = newRawBin(particleType, cmd.dataBuffer, 0, particleBytesSize)
+			continue
+		}
+
+		value, err := decodeParticle(particleType, cmd.dataBuffer, 0, particleBytesSize, policy.OrderedMaps)
+		if err != nil {
+			return nil, err
+		}
+
+		if bins == nil {
+			bins = map[string]interface{}{}
 		}
+		bins[name] = value
 	}
 
-	return newRecord(cmd.node, key, bins, generation, expiration), nil
+	return newRecord(cmd.node, key, bins, generation, expiration).setRawBins(raw, policy.OrderedMaps), nil
 }
 
 func (cmd *batchCommandGet) Execute() error {
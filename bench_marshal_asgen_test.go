@@ -0,0 +1,155 @@
+// Code generated by "asgen -internal -type=benchGenObject -output=bench_marshal_asgen_test.go"; DO NOT EDIT.
+
+package aerospike
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	. "github.com/aerospike/aerospike-client-go/types"
+)
+
+// ToBins implements ObjectMarshaler.
+func (o *benchGenObject) ToBins() ([]*Bin, error) {
+	bins := make([]*Bin, 0, 10)
+	bins = append(bins, &Bin{Name: "name", Value: NewStringValue(o.Name)})
+	if o.Nick != "" {
+		bins = append(bins, &Bin{Name: "nick", Value: NewStringValue(o.Nick)})
+	}
+	bins = append(bins, &Bin{Name: "Age", Value: NewLongValue(int64(o.Age))})
+	bins = append(bins, &Bin{Name: "Count", Value: NewIntegerValue(o.Count)})
+	bins = append(bins, &Bin{Name: "Total", Value: NewValue(o.Total)})
+	bins = append(bins, &Bin{Name: "Score", Value: NewFloatValue(o.Score)})
+	bins = append(bins, &Bin{Name: "Active", Value: NewBoolValue(o.Active)})
+	if o.Blob != nil {
+		bins = append(bins, &Bin{Name: "Blob", Value: NewBytesValue(o.Blob)})
+	}
+	if !o.Created.IsZero() {
+		bins = append(bins, &Bin{Name: "Created", Value: NewLongValue(o.Created.UTC().UnixNano())})
+	}
+	attrsJSON, err := json.Marshal(o.Attrs)
+	if err != nil {
+		return nil, err
+	}
+	bins = append(bins, &Bin{Name: "attrs", Value: NewBytesValue(attrsJSON)})
+	return bins, nil
+}
+
+// FromBins implements ObjectUnmarshaler.
+func (o *benchGenObject) FromBins(bins BinMap) error {
+	for name, value := range bins {
+		switch name {
+		case "name":
+			switch v := value.(type) {
+			case nil:
+			case string:
+				o.Name = v
+			default:
+				return NewAerospikeError(PARSE_ERROR, fmt.Sprintf("Can not set value of type %T to benchGenObject.Name", value))
+			}
+		case "nick":
+			switch v := value.(type) {
+			case nil:
+			case string:
+				o.Nick = v
+			default:
+				return NewAerospikeError(PARSE_ERROR, fmt.Sprintf("Can not set value of type %T to benchGenObject.Nick", value))
+			}
+		case "Age":
+			switch v := value.(type) {
+			case nil:
+			case int:
+				o.Age = int32(v)
+			default:
+				return NewAerospikeError(PARSE_ERROR, fmt.Sprintf("Can not set value of type %T to benchGenObject.Age", value))
+			}
+		case "Count":
+			switch v := value.(type) {
+			case nil:
+			case int:
+				o.Count = v
+			default:
+				return NewAerospikeError(PARSE_ERROR, fmt.Sprintf("Can not set value of type %T to benchGenObject.Count", value))
+			}
+		case "Total":
+			switch v := value.(type) {
+			case nil:
+			case int:
+				o.Total = uint64(v)
+			case []byte:
+				o.Total = new(big.Int).SetBytes(v).Uint64()
+			default:
+				return NewAerospikeError(PARSE_ERROR, fmt.Sprintf("Can not set value of type %T to benchGenObject.Total", value))
+			}
+		case "Score":
+			switch v := value.(type) {
+			case nil:
+			case float64:
+				o.Score = v
+			case int:
+				o.Score = math.Float64frombits(uint64(v))
+			default:
+				return NewAerospikeError(PARSE_ERROR, fmt.Sprintf("Can not set value of type %T to benchGenObject.Score", value))
+			}
+		case "Active":
+			switch v := value.(type) {
+			case nil:
+			case bool:
+				o.Active = v
+			case int:
+				o.Active = v != 0
+			default:
+				return NewAerospikeError(PARSE_ERROR, fmt.Sprintf("Can not set value of type %T to benchGenObject.Active", value))
+			}
+		case "Blob":
+			switch v := value.(type) {
+			case nil:
+			case []byte:
+				o.Blob = v
+			default:
+				return NewAerospikeError(PARSE_ERROR, fmt.Sprintf("Can not set value of type %T to benchGenObject.Blob", value))
+			}
+		case "Created":
+			switch v := value.(type) {
+			case nil:
+			case int:
+				o.Created = time.Unix(0, int64(v))
+			default:
+				return NewAerospikeError(PARSE_ERROR, fmt.Sprintf("Can not set value of type %T to benchGenObject.Created", value))
+			}
+		case "attrs":
+			switch v := value.(type) {
+			case nil:
+			case []byte:
+				if err := json.Unmarshal(v, &o.Attrs); err != nil {
+					return err
+				}
+			default:
+				return NewAerospikeError(PARSE_ERROR, fmt.Sprintf("Can not set value of type %T to benchGenObject.Attrs", value))
+			}
+		}
+	}
+	return nil
+}
+
+// MetadataWritePolicy implements ObjectMetadata.
+func (o *benchGenObject) MetadataWritePolicy(policy *WritePolicy) *WritePolicy {
+	res := *policy
+	if o.Generation != 0 {
+		res.Generation = int32(o.Generation)
+		res.GenerationPolicy = EXPECT_GEN_EQUAL
+	}
+	if o.TTL != 0 {
+		res.Expiration = int32(o.TTL)
+	}
+	return &res
+}
+
+// SetMetadata implements ObjectMetadata.
+func (o *benchGenObject) SetMetadata(generation, expiration int) {
+	o.Generation = uint32(generation)
+	o.TTL = expiration
+}
@@ -0,0 +1,134 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+//go:generate go run tools/asgen/asgen.go -internal -type=benchGenObject -output=bench_marshal_asgen_test.go
+
+// benchObject is marshalled with reflection.
+type benchObject struct {
+	Name    string `as:"name"`
+	Nick    string `as:"nick,omitempty"`
+	Age     int32
+	Count   int
+	Total   uint64
+	Score   float64
+	Active  bool
+	Blob    []byte
+	Created time.Time
+	Attrs   map[string]string `as:"attrs,json"`
+	Ignored string            `as:"-"`
+
+	Generation uint32 `asm:"gen"`
+	TTL        int    `asm:"ttl"`
+}
+
+// benchGenObject has the fields of benchObject, and is marshalled by the code
+// generated by asgen.
+type benchGenObject struct {
+	Name    string `as:"name"`
+	Nick    string `as:"nick,omitempty"`
+	Age     int32
+	Count   int
+	Total   uint64
+	Score   float64
+	Active  bool
+	Blob    []byte
+	Created time.Time
+	Attrs   map[string]string `as:"attrs,json"`
+	Ignored string            `as:"-"`
+
+	Generation uint32 `asm:"gen"`
+	TTL        int    `asm:"ttl"`
+}
+
+var benchCreated = time.Unix(0, 1234567890)
+
+func newBenchObject() *benchObject {
+	return &benchObject{
+		Name:    "Jack Shaftoe",
+		Age:     42,
+		Count:   7,
+		Total:   1 << 40,
+		Score:   1.5,
+		Active:  true,
+		Blob:    make([]byte, 100),
+		Created: benchCreated,
+		Attrs:   map[string]string{"company": "VOC"},
+	}
+}
+
+func newBenchGenObject() *benchGenObject {
+	obj := benchGenObject(*newBenchObject())
+	return &obj
+}
+
+// benchBinMap returns the bins as read back from the wire.
+func benchBinMap(bins []*Bin) BinMap {
+	binMap := make(BinMap, len(bins))
+	for _, bin := range bins {
+		buf := make([]byte, bin.Value.estimateSize())
+		n, _ := bin.Value.write(buf, 0)
+		binMap[bin.Name], _ = bytesToParticle(bin.Value.GetType(), buf, 0, n)
+	}
+	return binMap
+}
+
+func Benchmark_Marshal_Reflection(b *testing.B) {
+	obj := newBenchObject()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bins, _ := marshal(obj, false)
+		binPool.Put(bins)
+	}
+}
+
+func Benchmark_Marshal_Generated(b *testing.B) {
+	obj := newBenchGenObject()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		obj.ToBins()
+	}
+}
+
+func Benchmark_Unmarshal_Reflection(b *testing.B) {
+	bins, _ := marshal(newBenchObject(), false)
+	binMap := benchBinMap(bins)
+
+	obj := &benchObject{}
+	rv := reflect.ValueOf(obj).Elem()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for name, value := range binMap {
+			setObjectField(rv, name, value)
+		}
+	}
+}
+
+func Benchmark_Unmarshal_Generated(b *testing.B) {
+	bins, _ := newBenchGenObject().ToBins()
+	binMap := benchBinMap(bins)
+
+	obj := &benchGenObject{}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		obj.FromBins(binMap)
+	}
+}
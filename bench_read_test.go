@@ -0,0 +1,105 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"strings"
+	"testing"
+)
+
+// rawBins returns the bins in the wire protocol, as they are returned by the server.
+func rawBins(bins ...*Bin) []byte {
+	cmd := &baseCommand{dataBuffer: make([]byte, 4096)}
+	for _, bin := range bins {
+		cmd.writeOperationForBin(bin, READ)
+	}
+	return cmd.dataBuffer[:cmd.dataOffset]
+}
+
+var benchReadBins = []*Bin{
+	NewBin("price", 198),
+	NewBin("name", "Jack Shaftoe and Company"),
+	NewBin("blob", []byte(strings.Repeat(" ", 1000))),
+	NewBin("tags", []interface{}{"a", "b", 1}),
+}
+
+func Benchmark_Read_ParseRecord(b *testing.B) {
+	cmd := &readCommand{
+		singleCommand: &singleCommand{baseCommand: &baseCommand{dataBuffer: rawBins(benchReadBins...)}},
+		policy:        NewPolicy(),
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cmd.parseRecord(len(benchReadBins), 0, 0, 0)
+	}
+}
+
+func benchWideBins() []*Bin {
+	list := make([]interface{}, 100)
+	for i := range list {
+		list[i] = strings.Repeat("s", i%20)
+	}
+	m := make(map[interface{}]interface{}, 100)
+	for i := range list {
+		m[i] = float64(i)
+	}
+
+	return []*Bin{
+		NewBin("price", 198),
+		NewBin("list", list),
+		NewBin("map", m),
+	}
+}
+
+func benchParseRecord(b *testing.B, lazyBins bool) {
+	bins := benchWideBins()
+	policy := NewPolicy()
+	policy.LazyBins = lazyBins
+	cmd := &readCommand{
+		singleCommand: &singleCommand{baseCommand: &baseCommand{dataBuffer: rawBins(bins...)}},
+		policy:        policy,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cmd.parseRecord(len(bins), 0, 0, 0)
+	}
+}
+
+func Benchmark_Read_ParseRecord_Wide(b *testing.B) {
+	benchParseRecord(b, false)
+}
+
+func Benchmark_Read_ParseRecord_WideLazy(b *testing.B) {
+	benchParseRecord(b, true)
+}
+
+func Benchmark_Read_ParseRawBins(b *testing.B) {
+	buf := rawBins(benchReadBins...)
+	sum := 0
+	handler := func(binName string, particleType int, raw []byte) error {
+		sum += len(raw)
+		return nil
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		parseRawBins(buf, 0, len(benchReadBins), handler)
+	}
+}
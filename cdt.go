@@ -0,0 +1,137 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	ParticleType "github.com/aerospike/aerospike-client-go/types/particle_type"
+	Buffer "github.com/aerospike/aerospike-client-go/utils/buffer"
+)
+
+// cdtValue is the value of a collection data type (list and map)
+// operation. It is sent to the server as the operation code,
+// followed by the MessagePack array of the operation parameters.
+// Operations on nested lists and maps are sent as a single MessagePack
+// array of the context marker, the context path, and the operation.
+type cdtValue struct {
+	opType int16
+	params []Value
+	ctx    []*CDTContext
+
+	// packed params, and the error of packing them.
+	// The value is packed when it is created, and is read-only after
+	// that, so the operation can be shared by concurrent commands.
+	bytes []byte
+	err   error
+}
+
+func newCDTValue(opType int16, params ...Value) *cdtValue {
+	return newCDTContextValue(opType, params, nil)
+}
+
+// newCDTContextValue returns the value of the operation applied to
+// the nested list or map selected by the context path.
+func newCDTContextValue(opType int16, params []Value, ctx []*CDTContext) *cdtValue {
+	vl := &cdtValue{opType: opType, params: params, ctx: ctx}
+	vl.packParams()
+	return vl
+}
+
+func (vl *cdtValue) packParams() {
+	if len(vl.params) == 0 && len(vl.ctx) == 0 {
+		return
+	}
+
+	packer := newPacker()
+	if len(vl.ctx) > 0 {
+		vl.err = vl.packWithContext(packer)
+	} else {
+		vl.err = packer.packValueArray(vl.params)
+	}
+
+	if vl.err == nil {
+		vl.bytes = packer.buffer.Bytes()
+	}
+}
+
+func (vl *cdtValue) packWithContext(packer *packer) error {
+	packer.PackArrayBegin(3)
+	packer.PackAInt(0xff)
+
+	packer.PackArrayBegin(len(vl.ctx) * 2)
+	for _, ctx := range vl.ctx {
+		packer.PackAInt(ctx.id)
+		if err := ctx.value.pack(packer); err != nil {
+			return err
+		}
+	}
+
+	packer.PackArrayBegin(len(vl.params) + 1)
+	packer.PackAInt(int(vl.opType))
+	for _, param := range vl.params {
+		if err := param.pack(packer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (vl *cdtValue) estimateSize() int {
+	if len(vl.ctx) > 0 {
+		return len(vl.bytes)
+	}
+	return 2 + len(vl.bytes)
+}
+
+func (vl *cdtValue) write(buffer []byte, offset int) (int, error) {
+	if vl.err != nil {
+		return 0, vl.err
+	}
+
+	if len(vl.ctx) > 0 {
+		return copy(buffer[offset:], vl.bytes), nil
+	}
+
+	Buffer.Int16ToBytes(vl.opType, buffer, offset)
+	return 2 + copy(buffer[offset+2:], vl.bytes), nil
+}
+
+func (vl *cdtValue) pack(packer *packer) error {
+	return fmt.Errorf("CDT operations can not be used as values")
+}
+
+// GetType returns wire protocol value type.
+func (vl *cdtValue) GetType() int {
+	return ParticleType.BLOB
+}
+
+// GetObject returns original value as an interface{}.
+func (vl *cdtValue) GetObject() interface{} {
+	return vl.params
+}
+
+func (vl *cdtValue) reader() io.Reader {
+	buf := make([]byte, vl.estimateSize())
+	vl.write(buf, 0)
+	return bytes.NewReader(buf)
+}
+
+// String implements Stringer interface.
+func (vl *cdtValue) String() string {
+	return fmt.Sprintf("%d%v", vl.opType, vl.params)
+}
@@ -0,0 +1,97 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	. "github.com/aerospike/aerospike-client-go/types"
+)
+
+const (
+	_CDT_CTX_LIST_INDEX = 0x10
+	_CDT_CTX_LIST_RANK  = 0x11
+	_CDT_CTX_LIST_VALUE = 0x13
+	_CDT_CTX_MAP_INDEX  = 0x20
+	_CDT_CTX_MAP_RANK   = 0x21
+	_CDT_CTX_MAP_KEY    = 0x22
+	_CDT_CTX_MAP_VALUE  = 0x23
+)
+
+// CDTContext identifies one level of a nested list or map. A context path
+// is a slice of contexts, starting from the top level of the bin, which
+// selects the nested list or map that an operation applies to.
+//
+// For example, to increment profile.sessions[3].count in the "profile" bin:
+//
+//	MapIncrementOp(nil, "profile", "count", 1).WithContext(CtxMapKey("sessions"), CtxListIndex(3))
+type CDTContext struct {
+	id    int
+	value Value
+}
+
+// CtxListIndex selects the list item at the index.
+// A negative index counts backwards from the end of the list.
+func CtxListIndex(index int) *CDTContext {
+	return &CDTContext{id: _CDT_CTX_LIST_INDEX, value: NewIntegerValue(index)}
+}
+
+// CtxListRank selects the list item with the rank.
+// Rank 0 is the lowest value, and rank -1 the highest value.
+func CtxListRank(rank int) *CDTContext {
+	return &CDTContext{id: _CDT_CTX_LIST_RANK, value: NewIntegerValue(rank)}
+}
+
+// CtxListValue selects the list item with the value.
+func CtxListValue(value interface{}) *CDTContext {
+	return &CDTContext{id: _CDT_CTX_LIST_VALUE, value: NewValue(value)}
+}
+
+// CtxMapIndex selects the map item at the index.
+// A negative index counts backwards from the end of the map.
+func CtxMapIndex(index int) *CDTContext {
+	return &CDTContext{id: _CDT_CTX_MAP_INDEX, value: NewIntegerValue(index)}
+}
+
+// CtxMapRank selects the map item with the rank.
+// Rank 0 is the lowest value, and rank -1 the highest value.
+func CtxMapRank(rank int) *CDTContext {
+	return &CDTContext{id: _CDT_CTX_MAP_RANK, value: NewIntegerValue(rank)}
+}
+
+// CtxMapKey selects the map item with the key.
+func CtxMapKey(key interface{}) *CDTContext {
+	return &CDTContext{id: _CDT_CTX_MAP_KEY, value: NewValue(key)}
+}
+
+// CtxMapValue selects the map item with the value.
+func CtxMapValue(value interface{}) *CDTContext {
+	return &CDTContext{id: _CDT_CTX_MAP_VALUE, value: NewValue(value)}
+}
+
+// WithContext returns a copy of the list or map operation, which applies to
+// the nested list or map selected by the context path instead of the bin itself.
+// Applying a context to other operations fails the command with a PARAMETER_ERROR.
+// Nested contexts are only supported by Aerospike 4.6+ servers.
+func (op *Operation) WithContext(ctx ...*CDTContext) *Operation {
+	res := *op
+
+	vl, ok := op.BinValue.(*cdtValue)
+	if !ok {
+		res.BinValue = &cdtValue{err: NewAerospikeError(PARAMETER_ERROR, "Context is only supported by list and map operations")}
+		return &res
+	}
+
+	res.BinValue = newCDTContextValue(vl.opType, vl.params, append(append([]*CDTContext(nil), vl.ctx...), ctx...))
+	return &res
+}
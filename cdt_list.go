@@ -0,0 +1,191 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+// List bin operations. Create list operations used by the client's Operate command.
+// List operations support negative indexing. If the index is negative, the
+// resolved index starts backwards from the end of the list.
+//
+// Index/Range examples:
+//
+//   Index 0: First item in list.
+//   Index 4: Fifth item in list.
+//   Index -1: Last item in list.
+//   Index -3: Third to last item in list.
+//   Index 1 Count 2: Second and third items in list.
+//   Index -3 Count 3: Last three items in list.
+//   Index -5 Count 4: Range between fifth to last item to second to last item inclusive.
+//
+// If an index is out of bounds, a parameter error will be returned. If a range is partially
+// out of bounds, the valid part of the range will be returned.
+
+const (
+	_CDT_LIST_APPEND       = 1
+	_CDT_LIST_APPEND_ITEMS = 2
+	_CDT_LIST_INSERT       = 3
+	_CDT_LIST_INSERT_ITEMS = 4
+	_CDT_LIST_POP          = 5
+	_CDT_LIST_POP_RANGE    = 6
+	_CDT_LIST_REMOVE       = 7
+	_CDT_LIST_REMOVE_RANGE = 8
+	_CDT_LIST_SET          = 9
+	_CDT_LIST_TRIM         = 10
+	_CDT_LIST_CLEAR        = 11
+	_CDT_LIST_INCREMENT    = 12
+	_CDT_LIST_SORT         = 13
+	_CDT_LIST_SIZE         = 16
+	_CDT_LIST_GET          = 17
+	_CDT_LIST_GET_RANGE    = 18
+)
+
+// ListSortFlags determines the sort behavior of ListSortOp.
+type ListSortFlags int
+
+const (
+	// ListSortFlagsDefault sorts the list in ascending order, and keeps the duplicates.
+	ListSortFlagsDefault ListSortFlags = 0
+	// ListSortFlagsDropDuplicates drops the duplicate values while sorting.
+	ListSortFlagsDropDuplicates ListSortFlags = 2
+)
+
+func newListModifyOp(binName string, opType int16, params ...Value) *Operation {
+	return &Operation{OpType: CDT_MODIFY, BinName: binName, BinValue: newCDTValue(opType, params...)}
+}
+
+func newListReadOp(binName string, opType int16, params ...Value) *Operation {
+	return &Operation{OpType: CDT_READ, BinName: binName, BinValue: newCDTValue(opType, params...)}
+}
+
+// ListAppendOp creates a list append operation.
+// Server appends values to the end of list bin.
+// Server returns list size.
+func ListAppendOp(binName string, values ...interface{}) *Operation {
+	if len(values) == 1 {
+		return newListModifyOp(binName, _CDT_LIST_APPEND, NewValue(values[0]))
+	}
+	return newListModifyOp(binName, _CDT_LIST_APPEND_ITEMS, NewListValue(values))
+}
+
+// ListInsertOp creates a list insert operation.
+// Server inserts values at the specified index of the list bin.
+// Server returns list size.
+func ListInsertOp(binName string, index int, values ...interface{}) *Operation {
+	if len(values) == 1 {
+		return newListModifyOp(binName, _CDT_LIST_INSERT, NewIntegerValue(index), NewValue(values[0]))
+	}
+	return newListModifyOp(binName, _CDT_LIST_INSERT_ITEMS, NewIntegerValue(index), NewListValue(values))
+}
+
+// ListPopOp creates a list pop operation.
+// Server returns the item at the specified index and removes it from the list bin.
+func ListPopOp(binName string, index int) *Operation {
+	return newListModifyOp(binName, _CDT_LIST_POP, NewIntegerValue(index))
+}
+
+// ListPopRangeOp creates a list pop range operation.
+// Server returns count items starting at the specified index and removes them from the list bin.
+func ListPopRangeOp(binName string, index int, count int) *Operation {
+	return newListModifyOp(binName, _CDT_LIST_POP_RANGE, NewIntegerValue(index), NewIntegerValue(count))
+}
+
+// ListPopRangeFromOp creates a list pop range operation.
+// Server returns the items starting at the specified index to the end of the list,
+// and removes them from the list bin.
+func ListPopRangeFromOp(binName string, index int) *Operation {
+	return newListModifyOp(binName, _CDT_LIST_POP_RANGE, NewIntegerValue(index))
+}
+
+// ListRemoveOp creates a list remove operation.
+// Server removes the item at the specified index from the list bin.
+// Server returns the number of items removed.
+func ListRemoveOp(binName string, index int) *Operation {
+	return newListModifyOp(binName, _CDT_LIST_REMOVE, NewIntegerValue(index))
+}
+
+// ListRemoveRangeOp creates a list remove range operation.
+// Server removes count items starting at the specified index from the list bin.
+// Server returns the number of items removed.
+func ListRemoveRangeOp(binName string, index int, count int) *Operation {
+	return newListModifyOp(binName, _CDT_LIST_REMOVE_RANGE, NewIntegerValue(index), NewIntegerValue(count))
+}
+
+// ListRemoveRangeFromOp creates a list remove range operation.
+// Server removes the items starting at the specified index to the end of the list.
+// Server returns the number of items removed.
+func ListRemoveRangeFromOp(binName string, index int) *Operation {
+	return newListModifyOp(binName, _CDT_LIST_REMOVE_RANGE, NewIntegerValue(index))
+}
+
+// ListSetOp creates a list set operation.
+// Server sets the item value at the specified index in the list bin.
+// Server does not return a result by default.
+func ListSetOp(binName string, index int, value interface{}) *Operation {
+	return newListModifyOp(binName, _CDT_LIST_SET, NewIntegerValue(index), NewValue(value))
+}
+
+// ListTrimOp creates a list trim operation.
+// Server removes the items in the list bin that do not fall into the range
+// of count items starting at the specified index.
+// Server returns the number of items removed.
+func ListTrimOp(binName string, index int, count int) *Operation {
+	return newListModifyOp(binName, _CDT_LIST_TRIM, NewIntegerValue(index), NewIntegerValue(count))
+}
+
+// ListClearOp creates a list clear operation.
+// Server removes all items in the list bin.
+// Server does not return a result by default.
+func ListClearOp(binName string) *Operation {
+	return newListModifyOp(binName, _CDT_LIST_CLEAR)
+}
+
+// ListIncrementOp creates a list increment operation.
+// Server increments the item value at the specified index by the value.
+// Server returns the item's value after the increment.
+// This operation is only supported by Aerospike 3.15+ servers.
+func ListIncrementOp(binName string, index int, value interface{}) *Operation {
+	return newListModifyOp(binName, _CDT_LIST_INCREMENT, NewIntegerValue(index), NewValue(value))
+}
+
+// ListSortOp creates a list sort operation.
+// Server sorts the list bin according to the flags.
+// Server does not return a result by default.
+// This operation is only supported by Aerospike 3.15+ servers.
+func ListSortOp(binName string, sortFlags ListSortFlags) *Operation {
+	return newListModifyOp(binName, _CDT_LIST_SORT, NewIntegerValue(int(sortFlags)))
+}
+
+// ListSizeOp creates a list size operation.
+// Server returns the size of the list bin.
+func ListSizeOp(binName string) *Operation {
+	return newListReadOp(binName, _CDT_LIST_SIZE)
+}
+
+// ListGetOp creates a list get operation.
+// Server returns the item at the specified index in the list bin.
+func ListGetOp(binName string, index int) *Operation {
+	return newListReadOp(binName, _CDT_LIST_GET, NewIntegerValue(index))
+}
+
+// ListGetRangeOp creates a list get range operation.
+// Server returns count items starting at the specified index in the list bin.
+func ListGetRangeOp(binName string, index int, count int) *Operation {
+	return newListReadOp(binName, _CDT_LIST_GET_RANGE, NewIntegerValue(index), NewIntegerValue(count))
+}
+
+// ListGetRangeFromOp creates a list get range operation.
+// Server returns the items starting at the specified index to the end of the list bin.
+func ListGetRangeFromOp(binName string, index int) *Operation {
+	return newListReadOp(binName, _CDT_LIST_GET_RANGE, NewIntegerValue(index))
+}
@@ -0,0 +1,122 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike_test
+
+import (
+	. "github.com/aerospike/aerospike-client-go"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// ALL tests are isolated by SetName and Key, which are 50 random charachters
+var _ = Describe("CDT List Test", func() {
+	initTestVars()
+
+	// connection data
+	var client *Client
+	var err error
+	var ns = "test"
+	var set = randString(50)
+	var key *Key
+	var wpolicy = NewWritePolicy(0, 0)
+	const binName = "list"
+
+	client, err = NewClientWithPolicy(clientPolicy, *host, *port)
+	if err != nil {
+		panic(err)
+	}
+
+	BeforeEach(func() {
+		key, err = NewKey(ns, set, randString(50))
+		Expect(err).ToNot(HaveOccurred())
+
+		err = client.PutBins(wpolicy, key, NewBin(binName, []interface{}{1, 2, 3, 4, 5}))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	list := func() []interface{} {
+		rec, err := client.Get(nil, key, binName)
+		Expect(err).ToNot(HaveOccurred())
+		return rec.Bins[binName].([]interface{})
+	}
+
+	It("should append and insert items", func() {
+		rec, err := client.Operate(wpolicy, key, ListAppendOp(binName, 6))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal(6))
+
+		rec, err = client.Operate(wpolicy, key, ListAppendOp(binName, 7, 8))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal(8))
+
+		_, err = client.Operate(wpolicy, key, ListInsertOp(binName, 0, "a", "b"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list()).To(Equal([]interface{}{"a", "b", 1, 2, 3, 4, 5, 6, 7, 8}))
+	})
+
+	It("should pop and remove items", func() {
+		rec, err := client.Operate(wpolicy, key, ListPopOp(binName, -1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal(5))
+
+		rec, err = client.Operate(wpolicy, key, ListPopRangeOp(binName, 0, 2))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal([]interface{}{1, 2}))
+
+		rec, err = client.Operate(wpolicy, key, ListRemoveOp(binName, 0))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal(1))
+		Expect(list()).To(Equal([]interface{}{4}))
+
+		rec, err = client.Operate(wpolicy, key, ListRemoveRangeFromOp(binName, 0))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal(1))
+	})
+
+	It("should set, increment, trim, sort and clear items", func() {
+		_, err := client.Operate(wpolicy, key,
+			ListSetOp(binName, 0, 10),
+			ListIncrementOp(binName, 1, 5),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list()).To(Equal([]interface{}{10, 7, 3, 4, 5}))
+
+		_, err = client.Operate(wpolicy, key, ListSortOp(binName, ListSortFlagsDefault))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list()).To(Equal([]interface{}{3, 4, 5, 7, 10}))
+
+		rec, err := client.Operate(wpolicy, key, ListTrimOp(binName, 1, 2))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal(3))
+		Expect(list()).To(Equal([]interface{}{4, 5}))
+
+		_, err = client.Operate(wpolicy, key, ListClearOp(binName))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list()).To(BeEmpty())
+	})
+
+	It("should get items and return the results of all operations on the bin", func() {
+		rec, err := client.Operate(wpolicy, key,
+			ListSizeOp(binName),
+			ListGetOp(binName, 1),
+			ListGetRangeOp(binName, -2, 2),
+			ListGetRangeFromOp(binName, 3),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal(OpResults{5, 2, []interface{}{4, 5}, []interface{}{4, 5}}))
+	})
+
+})
@@ -0,0 +1,429 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+// Map bin operations. Create map operations used by the client's Operate command.
+// The default unique key map is unordered.
+//
+// All maps maintain an index and a rank. The index is the item offset from the start of the map,
+// for both unordered and ordered maps. The rank is the sorted index of the value component.
+// Map supports negative indexing for index and rank.
+//
+// Index/Range examples:
+//
+//   Index 0: First item in map.
+//   Index 4: Fifth item in map.
+//   Index -1: Last item in map.
+//   Index -3: Third to last item in map.
+//   Index 1 Count 2: Second and third items in map.
+//   Index -3 Count 3: Last three items in map.
+//   Index -5 Count 4: Range between fifth to last item to second to last item inclusive.
+//
+// Rank examples:
+//
+//   Rank 0: Item with lowest value rank in map.
+//   Rank 4: Fifth lowest ranked item in map.
+//   Rank -1: Item with highest ranked value in map.
+//   Rank -3: Item with third highest ranked value in map.
+//   Rank 1 Count 2: Second and third lowest ranked items in map.
+//   Rank -3 Count 3: Top three ranked items in map.
+//
+// Key and value ranges include the begin value and exclude the end value.
+// A nil begin value means the lowest value; a nil end value means the highest value.
+
+const (
+	_CDT_MAP_SET_TYPE                 = 64
+	_CDT_MAP_ADD                      = 65
+	_CDT_MAP_ADD_ITEMS                = 66
+	_CDT_MAP_PUT                      = 67
+	_CDT_MAP_PUT_ITEMS                = 68
+	_CDT_MAP_REPLACE                  = 69
+	_CDT_MAP_REPLACE_ITEMS            = 70
+	_CDT_MAP_INCREMENT                = 73
+	_CDT_MAP_DECREMENT                = 74
+	_CDT_MAP_CLEAR                    = 75
+	_CDT_MAP_REMOVE_BY_KEY            = 76
+	_CDT_MAP_REMOVE_BY_INDEX          = 77
+	_CDT_MAP_REMOVE_BY_RANK           = 79
+	_CDT_MAP_REMOVE_BY_KEY_LIST       = 81
+	_CDT_MAP_REMOVE_BY_VALUE          = 82
+	_CDT_MAP_REMOVE_BY_VALUE_LIST     = 83
+	_CDT_MAP_REMOVE_BY_KEY_INTERVAL   = 84
+	_CDT_MAP_REMOVE_BY_INDEX_RANGE    = 85
+	_CDT_MAP_REMOVE_BY_VALUE_INTERVAL = 86
+	_CDT_MAP_REMOVE_BY_RANK_RANGE     = 87
+	_CDT_MAP_SIZE                     = 96
+	_CDT_MAP_GET_BY_KEY               = 97
+	_CDT_MAP_GET_BY_INDEX             = 98
+	_CDT_MAP_GET_BY_RANK              = 100
+	_CDT_MAP_GET_BY_VALUE             = 102
+	_CDT_MAP_GET_BY_KEY_INTERVAL      = 103
+	_CDT_MAP_GET_BY_INDEX_RANGE       = 104
+	_CDT_MAP_GET_BY_VALUE_INTERVAL    = 105
+	_CDT_MAP_GET_BY_RANK_RANGE        = 106
+)
+
+type mapOrderType int
+
+// MapOrder defines map storage order.
+var MapOrder = struct {
+	// Map is not ordered. This is the default.
+	UNORDERED mapOrderType
+	// Order map by key.
+	KEY_ORDERED mapOrderType
+	// Order map by key, then value.
+	KEY_VALUE_ORDERED mapOrderType
+}{0, 1, 3}
+
+type mapWriteMode struct {
+	itemCommand  int16
+	itemsCommand int16
+}
+
+// MapWriteMode defines the write mode of map put operations.
+var MapWriteMode = struct {
+	// If the key already exists, the item will be overwritten.
+	// If the key does not exist, a new item will be created.
+	UPDATE *mapWriteMode
+	// If the key already exists, the item will be overwritten.
+	// If the key does not exist, the write will fail.
+	UPDATE_ONLY *mapWriteMode
+	// If the key already exists, the write will fail.
+	// If the key does not exist, a new item will be created.
+	CREATE_ONLY *mapWriteMode
+}{
+	&mapWriteMode{_CDT_MAP_PUT, _CDT_MAP_PUT_ITEMS},
+	&mapWriteMode{_CDT_MAP_REPLACE, _CDT_MAP_REPLACE_ITEMS},
+	&mapWriteMode{_CDT_MAP_ADD, _CDT_MAP_ADD_ITEMS},
+}
+
+type mapReturnType int
+
+// MapReturnType defines the map result type returned for map get and remove operations.
+var MapReturnType = struct {
+	// Do not return a result.
+	NONE mapReturnType
+	// Return key index order.
+	//
+	// 0 = first key
+	// N = Nth key
+	// -1 = last key
+	INDEX mapReturnType
+	// Return reverse key order.
+	//
+	// 0 = last key
+	// -1 = first key
+	REVERSE_INDEX mapReturnType
+	// Return value order.
+	//
+	// 0 = smallest value
+	// N = Nth smallest value
+	// -1 = largest value
+	RANK mapReturnType
+	// Return reverse value order.
+	//
+	// 0 = largest value
+	// N = Nth largest value
+	// -1 = smallest value
+	REVERSE_RANK mapReturnType
+	// Return count of items selected.
+	COUNT mapReturnType
+	// Return key for single key read and key list for range read.
+	KEY mapReturnType
+	// Return value for single key read and value list for range read.
+	VALUE mapReturnType
+	// Return key/value items. The result is a map.
+	KEY_VALUE mapReturnType
+}{0, 1, 2, 3, 4, 5, 6, 7, 8}
+
+// MapPolicy directives when creating a map and writing map items.
+type MapPolicy struct {
+	attributes mapOrderType
+	writeMode  *mapWriteMode
+}
+
+// NewMapPolicy creates a MapPolicy with the specified order and write mode.
+// If the write mode is nil, MapWriteMode.UPDATE is used.
+func NewMapPolicy(order mapOrderType, writeMode *mapWriteMode) *MapPolicy {
+	if writeMode == nil {
+		writeMode = MapWriteMode.UPDATE
+	}
+
+	return &MapPolicy{
+		attributes: order,
+		writeMode:  writeMode,
+	}
+}
+
+// DefaultMapPolicy returns the default map policy: an unordered map, updated
+// regardless of whether the keys exist.
+func DefaultMapPolicy() *MapPolicy {
+	return NewMapPolicy(MapOrder.UNORDERED, MapWriteMode.UPDATE)
+}
+
+func getUsableMapPolicy(policy *MapPolicy) *MapPolicy {
+	if policy == nil {
+		return DefaultMapPolicy()
+	}
+	return policy
+}
+
+// isMapOperation returns true if the operation is a map operation.
+func isMapOperation(op *Operation) bool {
+	if op.OpType != CDT_READ && op.OpType != CDT_MODIFY {
+		return false
+	}
+	vl, ok := op.BinValue.(*cdtValue)
+	return ok && vl.opType >= _CDT_MAP_SET_TYPE
+}
+
+func newMapModifyOp(binName string, opType int16, params ...Value) *Operation {
+	return &Operation{OpType: CDT_MODIFY, BinName: binName, BinValue: newCDTValue(opType, params...)}
+}
+
+func newMapReadOp(binName string, opType int16, params ...Value) *Operation {
+	return &Operation{OpType: CDT_READ, BinName: binName, BinValue: newCDTValue(opType, params...)}
+}
+
+// newMapRangeParams returns the params of a key or value range operation.
+// The end of the range is omitted when it is nil.
+func newMapRangeParams(returnType mapReturnType, begin, end interface{}) []Value {
+	params := []Value{NewIntegerValue(int(returnType)), NewValue(begin)}
+	if end != nil {
+		params = append(params, NewValue(end))
+	}
+	return params
+}
+
+// MapSetPolicyOp creates a set map policy operation.
+// Server sets the map policy attributes.
+// Server does not return a result.
+//
+// The required map policy attributes can be changed after the map is created.
+func MapSetPolicyOp(policy *MapPolicy, binName string) *Operation {
+	policy = getUsableMapPolicy(policy)
+	return newMapModifyOp(binName, _CDT_MAP_SET_TYPE, NewIntegerValue(int(policy.attributes)))
+}
+
+// MapPutOp creates a map put operation.
+// Server writes the key/value item to the map bin and returns the map size.
+//
+// The map policy dictates the type of map to create when it does not exist.
+// The map policy also specifies the mode used when writing items to the map.
+// The map attributes are not sent in the UPDATE_ONLY mode, since the map
+// must already exist.
+func MapPutOp(policy *MapPolicy, binName string, key interface{}, value interface{}) *Operation {
+	policy = getUsableMapPolicy(policy)
+	if policy.writeMode.itemCommand == _CDT_MAP_REPLACE {
+		return newMapModifyOp(binName, policy.writeMode.itemCommand, NewValue(key), NewValue(value))
+	}
+	return newMapModifyOp(binName, policy.writeMode.itemCommand, NewValue(key), NewValue(value), NewIntegerValue(int(policy.attributes)))
+}
+
+// MapPutItemsOp creates a map put items operation.
+// Server writes each map item to the map bin and returns the map size.
+//
+// The map policy dictates the type of map to create when it does not exist.
+// The map policy also specifies the mode used when writing items to the map.
+// The map attributes are not sent in the UPDATE_ONLY mode, since the map
+// must already exist.
+func MapPutItemsOp(policy *MapPolicy, binName string, amap map[interface{}]interface{}) *Operation {
+	policy = getUsableMapPolicy(policy)
+	if policy.writeMode.itemsCommand == _CDT_MAP_REPLACE_ITEMS {
+		return newMapModifyOp(binName, policy.writeMode.itemsCommand, NewMapValue(amap))
+	}
+	return newMapModifyOp(binName, policy.writeMode.itemsCommand, NewMapValue(amap), NewIntegerValue(int(policy.attributes)))
+}
+
+// MapIncrementOp creates a map increment operation.
+// Server increments the values by incr for all items identified by key and returns the final result.
+// Valid only for numbers.
+//
+// The map policy dictates the type of map to create when it does not exist.
+func MapIncrementOp(policy *MapPolicy, binName string, key interface{}, incr interface{}) *Operation {
+	policy = getUsableMapPolicy(policy)
+	return newMapModifyOp(binName, _CDT_MAP_INCREMENT, NewValue(key), NewValue(incr), NewIntegerValue(int(policy.attributes)))
+}
+
+// MapDecrementOp creates a map decrement operation.
+// Server decrements the values by decr for all items identified by key and returns the final result.
+// Valid only for numbers.
+//
+// The map policy dictates the type of map to create when it does not exist.
+func MapDecrementOp(policy *MapPolicy, binName string, key interface{}, decr interface{}) *Operation {
+	policy = getUsableMapPolicy(policy)
+	return newMapModifyOp(binName, _CDT_MAP_DECREMENT, NewValue(key), NewValue(decr), NewIntegerValue(int(policy.attributes)))
+}
+
+// MapClearOp creates a map clear operation.
+// Server removes all items in the map.
+// Server does not return a result.
+func MapClearOp(binName string) *Operation {
+	return newMapModifyOp(binName, _CDT_MAP_CLEAR)
+}
+
+// MapRemoveByKeyOp creates a map remove operation.
+// Server removes the map item identified by key and returns the removed data specified by returnType.
+func MapRemoveByKeyOp(binName string, key interface{}, returnType mapReturnType) *Operation {
+	return newMapModifyOp(binName, _CDT_MAP_REMOVE_BY_KEY, NewIntegerValue(int(returnType)), NewValue(key))
+}
+
+// MapRemoveByKeyListOp creates a map remove operation.
+// Server removes the map items identified by keys and returns the removed data specified by returnType.
+func MapRemoveByKeyListOp(binName string, keys []interface{}, returnType mapReturnType) *Operation {
+	return newMapModifyOp(binName, _CDT_MAP_REMOVE_BY_KEY_LIST, NewIntegerValue(int(returnType)), NewListValue(keys))
+}
+
+// MapRemoveByKeyRangeOp creates a map remove operation.
+// Server removes the map items identified by the key range (keyBegin inclusive, keyEnd exclusive).
+// If keyBegin is nil, the range is less than keyEnd.
+// If keyEnd is nil, the range is greater than or equal to keyBegin.
+// Server returns the removed data specified by returnType.
+func MapRemoveByKeyRangeOp(binName string, keyBegin interface{}, keyEnd interface{}, returnType mapReturnType) *Operation {
+	return newMapModifyOp(binName, _CDT_MAP_REMOVE_BY_KEY_INTERVAL, newMapRangeParams(returnType, keyBegin, keyEnd)...)
+}
+
+// MapRemoveByValueOp creates a map remove operation.
+// Server removes the map items identified by value and returns the removed data specified by returnType.
+func MapRemoveByValueOp(binName string, value interface{}, returnType mapReturnType) *Operation {
+	return newMapModifyOp(binName, _CDT_MAP_REMOVE_BY_VALUE, NewIntegerValue(int(returnType)), NewValue(value))
+}
+
+// MapRemoveByValueListOp creates a map remove operation.
+// Server removes the map items identified by values and returns the removed data specified by returnType.
+func MapRemoveByValueListOp(binName string, values []interface{}, returnType mapReturnType) *Operation {
+	return newMapModifyOp(binName, _CDT_MAP_REMOVE_BY_VALUE_LIST, NewIntegerValue(int(returnType)), NewListValue(values))
+}
+
+// MapRemoveByValueRangeOp creates a map remove operation.
+// Server removes the map items identified by the value range (valueBegin inclusive, valueEnd exclusive).
+// If valueBegin is nil, the range is less than valueEnd.
+// If valueEnd is nil, the range is greater than or equal to valueBegin.
+// Server returns the removed data specified by returnType.
+func MapRemoveByValueRangeOp(binName string, valueBegin interface{}, valueEnd interface{}, returnType mapReturnType) *Operation {
+	return newMapModifyOp(binName, _CDT_MAP_REMOVE_BY_VALUE_INTERVAL, newMapRangeParams(returnType, valueBegin, valueEnd)...)
+}
+
+// MapRemoveByIndexOp creates a map remove operation.
+// Server removes the map item identified by index and returns the removed data specified by returnType.
+func MapRemoveByIndexOp(binName string, index int, returnType mapReturnType) *Operation {
+	return newMapModifyOp(binName, _CDT_MAP_REMOVE_BY_INDEX, NewIntegerValue(int(returnType)), NewIntegerValue(index))
+}
+
+// MapRemoveByIndexRangeOp creates a map remove operation.
+// Server removes count map items starting at the specified index and returns the removed data specified by returnType.
+func MapRemoveByIndexRangeOp(binName string, index int, count int, returnType mapReturnType) *Operation {
+	return newMapModifyOp(binName, _CDT_MAP_REMOVE_BY_INDEX_RANGE, NewIntegerValue(int(returnType)), NewIntegerValue(index), NewIntegerValue(count))
+}
+
+// MapRemoveByIndexRangeFromOp creates a map remove operation.
+// Server removes the map items starting at the specified index to the end of the map
+// and returns the removed data specified by returnType.
+func MapRemoveByIndexRangeFromOp(binName string, index int, returnType mapReturnType) *Operation {
+	return newMapModifyOp(binName, _CDT_MAP_REMOVE_BY_INDEX_RANGE, NewIntegerValue(int(returnType)), NewIntegerValue(index))
+}
+
+// MapRemoveByRankOp creates a map remove operation.
+// Server removes the map item identified by rank and returns the removed data specified by returnType.
+func MapRemoveByRankOp(binName string, rank int, returnType mapReturnType) *Operation {
+	return newMapModifyOp(binName, _CDT_MAP_REMOVE_BY_RANK, NewIntegerValue(int(returnType)), NewIntegerValue(rank))
+}
+
+// MapRemoveByRankRangeOp creates a map remove operation.
+// Server removes count map items starting at the specified rank and returns the removed data specified by returnType.
+func MapRemoveByRankRangeOp(binName string, rank int, count int, returnType mapReturnType) *Operation {
+	return newMapModifyOp(binName, _CDT_MAP_REMOVE_BY_RANK_RANGE, NewIntegerValue(int(returnType)), NewIntegerValue(rank), NewIntegerValue(count))
+}
+
+// MapRemoveByRankRangeFromOp creates a map remove operation.
+// Server removes the map items starting at the specified rank to the last ranked item
+// and returns the removed data specified by returnType.
+func MapRemoveByRankRangeFromOp(binName string, rank int, returnType mapReturnType) *Operation {
+	return newMapModifyOp(binName, _CDT_MAP_REMOVE_BY_RANK_RANGE, NewIntegerValue(int(returnType)), NewIntegerValue(rank))
+}
+
+// MapSizeOp creates a map size operation.
+// Server returns the size of the map.
+func MapSizeOp(binName string) *Operation {
+	return newMapReadOp(binName, _CDT_MAP_SIZE)
+}
+
+// MapGetByKeyOp creates a map get by key operation.
+// Server selects the map item identified by key and returns the selected data specified by returnType.
+func MapGetByKeyOp(binName string, key interface{}, returnType mapReturnType) *Operation {
+	return newMapReadOp(binName, _CDT_MAP_GET_BY_KEY, NewIntegerValue(int(returnType)), NewValue(key))
+}
+
+// MapGetByKeyRangeOp creates a map get by key range operation.
+// Server selects the map items identified by the key range (keyBegin inclusive, keyEnd exclusive).
+// If keyBegin is nil, the range is less than keyEnd.
+// If keyEnd is nil, the range is greater than or equal to keyBegin.
+// Server returns the selected data specified by returnType.
+func MapGetByKeyRangeOp(binName string, keyBegin interface{}, keyEnd interface{}, returnType mapReturnType) *Operation {
+	return newMapReadOp(binName, _CDT_MAP_GET_BY_KEY_INTERVAL, newMapRangeParams(returnType, keyBegin, keyEnd)...)
+}
+
+// MapGetByValueOp creates a map get by value operation.
+// Server selects the map items identified by value and returns the selected data specified by returnType.
+func MapGetByValueOp(binName string, value interface{}, returnType mapReturnType) *Operation {
+	return newMapReadOp(binName, _CDT_MAP_GET_BY_VALUE, NewIntegerValue(int(returnType)), NewValue(value))
+}
+
+// MapGetByValueRangeOp creates a map get by value range operation.
+// Server selects the map items identified by the value range (valueBegin inclusive, valueEnd exclusive).
+// If valueBegin is nil, the range is less than valueEnd.
+// If valueEnd is nil, the range is greater than or equal to valueBegin.
+// Server returns the selected data specified by returnType.
+func MapGetByValueRangeOp(binName string, valueBegin interface{}, valueEnd interface{}, returnType mapReturnType) *Operation {
+	return newMapReadOp(binName, _CDT_MAP_GET_BY_VALUE_INTERVAL, newMapRangeParams(returnType, valueBegin, valueEnd)...)
+}
+
+// MapGetByIndexOp creates a map get by index operation.
+// Server selects the map item identified by index and returns the selected data specified by returnType.
+func MapGetByIndexOp(binName string, index int, returnType mapReturnType) *Operation {
+	return newMapReadOp(binName, _CDT_MAP_GET_BY_INDEX, NewIntegerValue(int(returnType)), NewIntegerValue(index))
+}
+
+// MapGetByIndexRangeOp creates a map get by index range operation.
+// Server selects count map items starting at the specified index and returns the selected data specified by returnType.
+func MapGetByIndexRangeOp(binName string, index int, count int, returnType mapReturnType) *Operation {
+	return newMapReadOp(binName, _CDT_MAP_GET_BY_INDEX_RANGE, NewIntegerValue(int(returnType)), NewIntegerValue(index), NewIntegerValue(count))
+}
+
+// MapGetByIndexRangeFromOp creates a map get by index range operation.
+// Server selects the map items starting at the specified index to the end of the map
+// and returns the selected data specified by returnType.
+func MapGetByIndexRangeFromOp(binName string, index int, returnType mapReturnType) *Operation {
+	return newMapReadOp(binName, _CDT_MAP_GET_BY_INDEX_RANGE, NewIntegerValue(int(returnType)), NewIntegerValue(index))
+}
+
+// MapGetByRankOp creates a map get by rank operation.
+// Server selects the map item identified by rank and returns the selected data specified by returnType.
+func MapGetByRankOp(binName string, rank int, returnType mapReturnType) *Operation {
+	return newMapReadOp(binName, _CDT_MAP_GET_BY_RANK, NewIntegerValue(int(returnType)), NewIntegerValue(rank))
+}
+
+// MapGetByRankRangeOp creates a map get by rank range operation.
+// Server selects count map items starting at the specified rank and returns the selected data specified by returnType.
+func MapGetByRankRangeOp(binName string, rank int, count int, returnType mapReturnType) *Operation {
+	return newMapReadOp(binName, _CDT_MAP_GET_BY_RANK_RANGE, NewIntegerValue(int(returnType)), NewIntegerValue(rank), NewIntegerValue(count))
+}
+
+// MapGetByRankRangeFromOp creates a map get by rank range operation.
+// Server selects the map items starting at the specified rank to the last ranked item
+// and returns the selected data specified by returnType.
+func MapGetByRankRangeFromOp(binName string, rank int, returnType mapReturnType) *Operation {
+	return newMapReadOp(binName, _CDT_MAP_GET_BY_RANK_RANGE, NewIntegerValue(int(returnType)), NewIntegerValue(rank))
+}
@@ -0,0 +1,162 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike_test
+
+import (
+	. "github.com/aerospike/aerospike-client-go"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// ALL tests are isolated by SetName and Key, which are 50 random charachters
+var _ = Describe("CDT Map Test", func() {
+	initTestVars()
+
+	// connection data
+	var client *Client
+	var err error
+	var ns = "test"
+	var set = randString(50)
+	var key *Key
+	var wpolicy = NewWritePolicy(0, 0)
+	var mpolicy = NewMapPolicy(MapOrder.KEY_ORDERED, MapWriteMode.UPDATE)
+	const binName = "scores"
+
+	client, err = NewClientWithPolicy(clientPolicy, *host, *port)
+	if err != nil {
+		panic(err)
+	}
+
+	BeforeEach(func() {
+		key, err = NewKey(ns, set, randString(50))
+		Expect(err).ToNot(HaveOccurred())
+
+		rec, err := client.Operate(wpolicy, key, MapPutItemsOp(mpolicy, binName, map[interface{}]interface{}{
+			"alice": 30,
+			"bob":   10,
+			"carol": 20,
+			"dave":  40,
+		}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal(4))
+	})
+
+	It("should put, increment and decrement items", func() {
+		rec, err := client.Operate(wpolicy, key,
+			MapPutOp(mpolicy, binName, "erin", 5),
+			MapIncrementOp(mpolicy, binName, "bob", 15),
+			MapDecrementOp(mpolicy, binName, "dave", 1),
+			MapSizeOp(binName),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal(OpResults{5, 25, 39, 5}))
+	})
+
+	It("should respect the write mode", func() {
+		_, err := client.Operate(wpolicy, key, MapPutOp(NewMapPolicy(MapOrder.KEY_ORDERED, MapWriteMode.CREATE_ONLY), binName, "bob", 1))
+		Expect(err).To(HaveOccurred())
+
+		_, err = client.Operate(wpolicy, key, MapPutOp(NewMapPolicy(MapOrder.KEY_ORDERED, MapWriteMode.UPDATE_ONLY), binName, "zed", 1))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should get items by key, value and rank", func() {
+		rec, err := client.Operate(wpolicy, key,
+			MapGetByKeyOp(binName, "alice", MapReturnType.VALUE),
+			MapGetByKeyRangeOp(binName, "b", "d", MapReturnType.KEY),
+			MapGetByValueRangeOp(binName, 20, nil, MapReturnType.COUNT),
+			MapGetByRankRangeOp(binName, -2, 2, MapReturnType.KEY_VALUE),
+			MapGetByIndexOp(binName, 0, MapReturnType.KEY),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal(OpResults{
+			30,
+			[]interface{}{"bob", "carol"},
+			3,
+			map[interface{}]interface{}{"alice": 30, "dave": 40},
+			"alice",
+		}))
+	})
+
+	It("should remove items by key, value and rank", func() {
+		rec, err := client.Operate(wpolicy, key,
+			MapRemoveByKeyOp(binName, "alice", MapReturnType.VALUE),
+			MapRemoveByValueOp(binName, 10, MapReturnType.KEY),
+			MapRemoveByRankOp(binName, -1, MapReturnType.KEY),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal(OpResults{30, []interface{}{"bob"}, "dave"}))
+
+		rec, err = client.Get(nil, key, binName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal(map[interface{}]interface{}{"carol": 20}))
+
+		_, err = client.Operate(wpolicy, key, MapClearOp(binName))
+		Expect(err).ToNot(HaveOccurred())
+
+		rec, err = client.Operate(wpolicy, key, MapSizeOp(binName))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal(0))
+	})
+
+	It("should operate on nested lists and maps", func() {
+		err := client.PutBins(wpolicy, key, NewBin("profile", map[interface{}]interface{}{
+			"sessions": []interface{}{
+				map[interface{}]interface{}{"count": 1},
+				map[interface{}]interface{}{"count": 2},
+			},
+		}))
+		Expect(err).ToNot(HaveOccurred())
+
+		rec, err := client.Operate(wpolicy, key,
+			MapIncrementOp(nil, "profile", "count", 5).WithContext(CtxMapKey("sessions"), CtxListIndex(-1)),
+			ListSizeOp("profile").WithContext(CtxMapKey("sessions")),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins["profile"]).To(Equal(OpResults{7, 2}))
+
+		_, err = client.Operate(wpolicy, key, AddOp(NewBin("profile", 1)).WithContext(CtxMapKey("sessions")))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should read key ordered maps in their order", func() {
+		policy := NewPolicy()
+		policy.OrderedMaps = true
+
+		rec, err := client.Get(policy, key, binName)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins[binName]).To(Equal([]MapPair{{"alice", 30}, {"bob", 10}, {"carol", 20}, {"dave", 40}}))
+
+		err = client.PutBins(wpolicy, key, NewBin("pairs", []MapPair{{"z", 1}, {"a", 2}}))
+		Expect(err).ToNot(HaveOccurred())
+
+		rec, err = client.Get(nil, key, "pairs")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins["pairs"]).To(Equal(map[interface{}]interface{}{"z": 1, "a": 2}))
+
+		// stored as a key ordered map
+		err = client.PutBins(wpolicy, key, NewBin("sorted", NewOrderedMapValueWithOrder([]MapPair{{"a", 2}, {"z", 1}}, MapOrder.KEY_ORDERED)))
+		Expect(err).ToNot(HaveOccurred())
+
+		rec, err = client.Operate(wpolicy, key,
+			MapPutOp(nil, "sorted", "m", 3),
+			MapGetByIndexOp("sorted", 1, MapReturnType.KEY),
+		)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(rec.Bins["sorted"]).To(Equal(OpResults{3, "m"}))
+	})
+
+})
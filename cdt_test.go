@@ -0,0 +1,193 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CDT Value Test", func() {
+
+	It("should write the operation code followed by the packed params", func() {
+		vl := ListInsertOp("bin", 1, "a").BinValue
+
+		buf := make([]byte, vl.estimateSize())
+		n, err := vl.write(buf, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(len(buf)))
+
+		// INSERT, then the array [1, "a"]
+		Expect(buf).To(Equal([]byte{0, _CDT_LIST_INSERT, 0x92, 0x01, 0xa2, 0x03, 'a'}))
+	})
+
+	It("should write the operation code without params", func() {
+		vl := ListSizeOp("bin").BinValue
+
+		buf := make([]byte, vl.estimateSize())
+		_, err := vl.write(buf, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(buf).To(Equal([]byte{0, _CDT_LIST_SIZE}))
+	})
+
+	It("should select the single or multiple item operation", func() {
+		Expect(ListAppendOp("bin", 1).BinValue.(*cdtValue).opType).To(Equal(int16(_CDT_LIST_APPEND)))
+		Expect(ListAppendOp("bin", 1, 2).BinValue.(*cdtValue).opType).To(Equal(int16(_CDT_LIST_APPEND_ITEMS)))
+		Expect(ListInsertOp("bin", 0, 1, 2).BinValue.(*cdtValue).opType).To(Equal(int16(_CDT_LIST_INSERT_ITEMS)))
+		Expect(ListGetOp("bin", 0).OpType).To(Equal(CDT_READ))
+		Expect(ListPopOp("bin", 0).OpType).To(Equal(CDT_MODIFY))
+	})
+
+	It("should write the map policy attributes and the write mode", func() {
+		policy := NewMapPolicy(MapOrder.KEY_ORDERED, MapWriteMode.CREATE_ONLY)
+		vl := MapPutOp(policy, "bin", "k", 1).BinValue
+
+		buf := make([]byte, vl.estimateSize())
+		_, err := vl.write(buf, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		// ADD, then the array ["k", 1, KEY_ORDERED]
+		Expect(buf).To(Equal([]byte{0, _CDT_MAP_ADD, 0x93, 0xa2, 0x03, 'k', 0x01, 0x01}))
+
+		vl = MapPutItemsOp(nil, "bin", map[interface{}]interface{}{"k": 1}).BinValue
+		Expect(vl.(*cdtValue).opType).To(Equal(int16(_CDT_MAP_PUT_ITEMS)))
+	})
+
+	It("should default to the UPDATE write mode", func() {
+		policy := NewMapPolicy(MapOrder.KEY_ORDERED, nil)
+		Expect(policy.writeMode).To(Equal(MapWriteMode.UPDATE))
+
+		vl := MapPutOp(policy, "bin", "k", 1).BinValue.(*cdtValue)
+		Expect(vl.opType).To(Equal(int16(_CDT_MAP_PUT)))
+	})
+
+	It("should write the return type first, and omit a nil range end", func() {
+		vl := MapGetByKeyRangeOp("bin", "a", nil, MapReturnType.COUNT).BinValue
+
+		buf := make([]byte, vl.estimateSize())
+		_, err := vl.write(buf, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		// GET_BY_KEY_INTERVAL, then the array [COUNT, "a"]
+		Expect(buf).To(Equal([]byte{0, _CDT_MAP_GET_BY_KEY_INTERVAL, 0x92, 0x05, 0xa2, 0x03, 'a'}))
+
+		Expect(MapGetByKeyRangeOp("bin", nil, "z", MapReturnType.KEY).BinValue.(*cdtValue).params).To(HaveLen(3))
+		Expect(MapRemoveByRankRangeOp("bin", 0, 2, MapReturnType.KEY).OpType).To(Equal(CDT_MODIFY))
+		Expect(MapGetByRankRangeOp("bin", 0, 2, MapReturnType.KEY).OpType).To(Equal(CDT_READ))
+	})
+
+	It("should write nested operations as the context path followed by the operation", func() {
+		op := MapIncrementOp(nil, "bin", "count", 1).WithContext(CtxMapKey("sessions"), CtxListIndex(3))
+		vl := op.BinValue
+
+		buf := make([]byte, vl.estimateSize())
+		n, err := vl.write(buf, 0)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(len(buf)))
+
+		Expect(buf).To(Equal([]byte{
+			0x93, 0xcc, 0xff,
+			// ["sessions", 3]
+			0x94, _CDT_CTX_MAP_KEY, 0xa9, 0x03, 's', 'e', 's', 's', 'i', 'o', 'n', 's', _CDT_CTX_LIST_INDEX, 0x03,
+			// [INCREMENT, "count", 1, UNORDERED]
+			0x94, _CDT_MAP_INCREMENT, 0xa6, 0x03, 'c', 'o', 'u', 'n', 't', 0x01, 0x00,
+		}))
+
+		Expect(op.OpType).To(Equal(CDT_MODIFY))
+		Expect(op.BinName).To(Equal("bin"))
+	})
+
+	It("should not change the original operation", func() {
+		op := ListSizeOp("bin")
+		nested := op.WithContext(CtxListIndex(0))
+
+		Expect(op.BinValue.(*cdtValue).ctx).To(BeEmpty())
+		Expect(nested.WithContext(CtxMapRank(-1)).BinValue.(*cdtValue).ctx).To(HaveLen(2))
+		Expect(nested.BinValue.(*cdtValue).ctx).To(HaveLen(1))
+	})
+
+	It("should pack the operation when it is created, so that it can be shared by concurrent commands", func() {
+		op := ListAppendOp("bin", 1, "a").WithContext(CtxListIndex(0))
+		vl := op.BinValue.(*cdtValue)
+		Expect(vl.bytes).ToNot(BeEmpty())
+
+		expected := make([]byte, vl.estimateSize())
+		_, err := vl.write(expected, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		var wg sync.WaitGroup
+		wg.Add(10)
+		for i := 0; i < 10; i++ {
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				buf := make([]byte, vl.estimateSize())
+				_, err := vl.write(buf, 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(buf).To(Equal(expected))
+			}()
+		}
+		wg.Wait()
+	})
+
+	It("should reject context on operations other than list and map operations", func() {
+		vl := AddOp(NewBin("bin", 1)).WithContext(CtxListIndex(0)).BinValue
+
+		buf := make([]byte, vl.estimateSize())
+		_, err := vl.write(buf, 0)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not send the map attributes in the UPDATE_ONLY write mode", func() {
+		// the operation code, and the number of packed params
+		header := func(op *Operation) []byte {
+			vl := op.BinValue.(*cdtValue)
+			buf := make([]byte, vl.estimateSize())
+			_, err := vl.write(buf, 0)
+			Expect(err).ToNot(HaveOccurred())
+			return buf[1:3]
+		}
+
+		items := map[interface{}]interface{}{"a": 1}
+		for mode, params := range map[*mapWriteMode][]byte{
+			MapWriteMode.UPDATE:      {0x93, 0x92},
+			MapWriteMode.UPDATE_ONLY: {0x92, 0x91},
+			MapWriteMode.CREATE_ONLY: {0x93, 0x92},
+		} {
+			policy := NewMapPolicy(MapOrder.KEY_ORDERED, mode)
+			Expect(header(MapPutOp(policy, "bin", "a", 1))).To(Equal([]byte{byte(mode.itemCommand), params[0]}))
+			Expect(header(MapPutItemsOp(policy, "bin", items))).To(Equal([]byte{byte(mode.itemsCommand), params[1]}))
+		}
+	})
+
+	It("should ask the server to respond to all operations when there are map operations", func() {
+		key, err := NewKey("test", "demo", 1)
+		Expect(err).ToNot(HaveOccurred())
+
+		writeAttr := func(operations ...*Operation) int {
+			cmd := &baseCommand{}
+			Expect(cmd.setOperate(NewWritePolicy(0, 0), key, operations)).To(Succeed())
+			return int(cmd.dataBuffer[10])
+		}
+
+		Expect(writeAttr(ListAppendOp("list", 1), GetOpForBin("list"))).To(Equal(_INFO2_WRITE))
+		Expect(writeAttr(MapSizeOp("map"), GetOpForBin("list"))).To(Equal(_INFO2_RESPOND_ALL_OPS))
+		Expect(writeAttr(MapPutOp(nil, "map", "a", 1), GetOpForBin("map"))).To(Equal(_INFO2_WRITE | _INFO2_RESPOND_ALL_OPS))
+	})
+
+})
@@ -20,12 +20,14 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
-	"reflect"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
+	"github.com/aerospike/aerospike-client-go/internal/lua"
 	. "github.com/aerospike/aerospike-client-go/types"
+	ilua "github.com/yuin/gopher-lua"
 )
 
 // Client encapsulates an Aerospike cluster.
@@ -141,13 +143,51 @@ func (clnt *Client) PutBins(policy *WritePolicy, key *Key, bins ...*Bin) error {
 }
 
 // PutObject writes record bin(s) to the server.
+// Each exported field of the struct is written to a bin. Nested structs, pointers,
+// slices, maps and time.Time values are supported, and are read back by GetObject.
+// Objects implementing BinMarshaler write their own bins, and fields implementing
+// ValueMarshaler write their own values. Objects implementing ObjectMarshaler, usually
+// generated by the asgen tool, are written without reflection.
+// Fields are mapped to bins with the `as:"name,options"` tag, where the options are:
+// omitempty to skip the field when empty, json or msgpack to store the field as a blob
+// in that format, and key to mark the field the user key is derived from.
+// If the object has a key field, the record is written with the key derived from the
+// namespace and the set name of the passed key and the value of that field; otherwise
+// the passed key is used. A nil key returns a PARAMETER_ERROR.
 // The policy specifies the transaction timeout, record expiration and how the transaction is
-// handled when the record already exists.
+// handled when the record already exists. The fields tagged with `asm:"gen"` and `asm:"ttl"`
+// receive the record's generation and expiration in GetObject. When not zero, they set the
+// record expiration, and the generation the record is expected to have, as EXPECT_GEN_EQUAL.
+// Bool fields are stored as integers 1 and 0, unless the policy's UseBoolBin is set.
 // If the policy is nil, the default relevant policy will be used.
 func (clnt *Client) PutObject(policy *WritePolicy, key *Key, obj interface{}) (err error) {
+	key, err = objectKey(key, obj)
+	if err != nil {
+		return err
+	}
+
 	policy = clnt.getUsableWritePolicy(policy)
 
-	bins := marshal(obj)
+	if om, ok := obj.(ObjectMarshaler); ok {
+		if md, ok := obj.(ObjectMetadata); ok {
+			policy = md.MetadataWritePolicy(policy)
+		}
+
+		bins, err := om.ToBins()
+		if err != nil {
+			return err
+		}
+		if !policy.UseBoolBin {
+			boolBinsToIntegers(bins)
+		}
+		return newWriteCommand(clnt.cluster, policy, key, bins, WRITE).Execute()
+	}
+
+	policy = objectWritePolicy(policy, obj)
+	bins, err := marshal(obj, policy.UseBoolBin)
+	if err != nil {
+		return err
+	}
 	command := newWriteCommand(clnt.cluster, policy, key, bins, WRITE)
 	res := command.Execute()
 	binPool.Put(bins)
@@ -302,13 +342,39 @@ func (clnt *Client) Get(policy *BasePolicy, key *Key, binNames ...string) (*Reco
 	return command.GetRecord(), nil
 }
 
+// GetWithHandler reads the bins of the record for specified key, and calls the handler
+// for each bin with its raw value, straight from the command buffer. Unlike Get, it does
+// not allocate a Record, nor copy the bin names and values.
+// Returns false if the record does not exist.
+// The policy can be used to specify timeouts.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) GetWithHandler(policy *BasePolicy, key *Key, handler BinHandler, binNames ...string) (bool, error) {
+	policy = clnt.getUsablePolicy(policy)
+
+	command := newReadHandlerCommand(clnt.cluster, policy, key, binNames, handler)
+	if err := command.Execute(); err != nil {
+		return false, err
+	}
+	return command.found, nil
+}
+
 // GetObject reads a record for specified key and puts the result into the provided object.
+// Objects implementing BinUnmarshaler read their own bins, and fields implementing
+// ValueUnmarshaler read their own values. Objects implementing ObjectUnmarshaler, usually
+// generated by the asgen tool, are read without reflection.
+// The fields tagged with `asm:"gen"` and `asm:"ttl"` receive the record's generation and expiration.
 // The policy can be used to specify timeouts.
 // If the policy is nil, the default relevant policy will be used.
 func (clnt *Client) GetObject(policy *BasePolicy, key *Key, obj interface{}) error {
 	policy = clnt.getUsablePolicy(policy)
 
-	binNames := objectMappings.getFields(reflect.ValueOf(obj).Type().Elem().Name())
+	// objects reading their own bins read all bins
+	var binNames []string
+	if _, ok := obj.(ObjectUnmarshaler); !ok {
+		if _, ok := implementation(obj, binUnmarshalerType); !ok {
+			binNames = objectBinNames(obj)
+		}
+	}
 	command := newReadCommand(clnt.cluster, policy, key, binNames)
 	command.object = obj
 	if err := command.Execute(); err != nil {
@@ -431,6 +497,7 @@ func (clnt *Client) ScanAll(apolicy *ScanPolicy, namespace string, setName strin
 
 	// result recordset
 	res := newRecordset(policy.RecordQueueSize, len(nodes))
+	res.taskId = newTaskId()
 
 	// the whole call should be wrapped in a goroutine
 	if policy.ConcurrentNodes {
@@ -467,11 +534,63 @@ func (clnt *Client) ScanNode(apolicy *ScanPolicy, node *Node, namespace string,
 
 	// results channel must be async for performance
 	res := newRecordset(policy.RecordQueueSize, 1)
+	res.taskId = newTaskId()
 
 	go clnt.scanNode(&policy, node, res, namespace, setName, binNames...)
 	return res, nil
 }
 
+// ScanAllWithHandler reads all records in specified namespace and set from all nodes,
+// and calls the handler for each bin with its raw value, straight from the command buffer.
+// Unlike ScanAll, it does not allocate Records, nor copy the bin names and values.
+// If the policy's ConcurrentNodes is specified, each server node will be read in
+// parallel, and the handler will be called concurrently; the first error is returned
+// after all nodes have completed. Otherwise nodes are read one by one, and the scan
+// stops at the first error.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) ScanAllWithHandler(apolicy *ScanPolicy, namespace string, setName string, handler ScanHandler, binNames ...string) error {
+	policy := *clnt.getUsableScanPolicy(apolicy)
+
+	nodes := clnt.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return NewAerospikeError(SERVER_NOT_AVAILABLE, "Scan failed because cluster is empty.")
+	}
+
+	if policy.WaitUntilMigrationsAreOver {
+		// wait until all migrations are finished
+		if err := clnt.cluster.WaitUntillMigrationIsFinished(policy.Timeout); err != nil {
+			return err
+		}
+	}
+
+	taskId := newTaskId()
+	if !policy.ConcurrentNodes {
+		// scan nodes one by one
+		for _, node := range nodes {
+			command := newScanHandlerCommand(node, &policy, namespace, setName, binNames, taskId, handler)
+			if err := command.Execute(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	errs := make(chan error, len(nodes))
+	for _, node := range nodes {
+		go func(node *Node) {
+			errs <- newScanHandlerCommand(node, &policy, namespace, setName, binNames, taskId, handler).Execute()
+		}(node)
+	}
+
+	var res error
+	for range nodes {
+		if err := <-errs; err != nil && res == nil {
+			res = err
+		}
+	}
+	return res
+}
+
 // ScanNode reads all records in specified namespace and set for one node only.
 // If the policy is nil, the default relevant policy will be used.
 func (clnt *Client) scanNode(policy *ScanPolicy, node *Node, recordset *Recordset, namespace string, setName string, binNames ...string) error {
@@ -483,7 +602,7 @@ func (clnt *Client) scanNode(policy *ScanPolicy, node *Node, recordset *Recordse
 		}
 	}
 
-	command := newScanCommand(node, policy, namespace, setName, binNames, recordset)
+	command := newScanCommand(node, policy, namespace, setName, binNames, recordset, recordset.taskId)
 	return command.Execute()
 }
 
@@ -737,6 +856,100 @@ func (clnt *Client) ListUDF(policy *BasePolicy) ([]*UDF, error) {
 	return res, nil
 }
 
+// GetUDF returns the body of the UDF package from the server.
+// This method is only supported by Aerospike 3 servers.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) GetUDF(policy *BasePolicy, udfName string) ([]byte, error) {
+	policy = clnt.getUsablePolicy(policy)
+
+	node, err := clnt.cluster.GetRandomNode()
+	if err != nil {
+		return nil, err
+	}
+
+	command := "udf-get:filename=" + udfName
+	responseMap, err := requestNodeInfo(node, policy.Timeout, command)
+	if err != nil {
+		return nil, err
+	}
+
+	res := parseInfoValues(responseMap[command], ";")
+	if udfErr, exists := res["error"]; exists {
+		return nil, NewAerospikeError(SERVER_ERROR, "Getting UDF "+udfName+" failed: "+udfErr)
+	}
+
+	content, exists := res["content"]
+	if !exists {
+		return nil, NewAerospikeError(PARSE_ERROR, "Invalid udf-get response for "+udfName)
+	}
+
+	return base64.StdEncoding.DecodeString(content)
+}
+
+// SyncUDFs synchronizes the UDF packages on the server with the Lua modules
+// in the directory. Only the modules missing on the server, or whose hash
+// differs from the server's, are registered. If removeUnknown is set, the
+// packages on the server which are not in the directory are removed.
+// SyncUDFs waits for all registrations and removals to complete,
+// and returns the names of the registered and removed packages.
+// This method is only supported by Aerospike 3 servers.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) SyncUDFs(policy *WritePolicy, dir string, removeUnknown bool) (registered, removed []string, err error) {
+	policy = clnt.getUsableWritePolicy(policy)
+
+	modules, err := readUDFDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	udfs, err := clnt.ListUDF(&policy.BasePolicy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serverHashes := make(map[string]string, len(udfs))
+	for _, udf := range udfs {
+		serverHashes[udf.Filename] = udf.Hash
+	}
+
+	var tasks []Task
+	for name, udfBody := range modules {
+		if hash, exists := serverHashes[name]; exists && strings.EqualFold(hash, udfHash(udfBody)) {
+			continue
+		}
+
+		task, err := clnt.RegisterUDF(policy, udfBody, name, LUA)
+		if err != nil {
+			return registered, removed, err
+		}
+		tasks = append(tasks, task)
+		registered = append(registered, name)
+	}
+
+	if removeUnknown {
+		for _, udf := range udfs {
+			if _, exists := modules[udf.Filename]; exists {
+				continue
+			}
+
+			task, err := clnt.RemoveUDF(policy, udf.Filename)
+			if err != nil {
+				return registered, removed, err
+			}
+			tasks = append(tasks, task)
+			removed = append(removed, udf.Filename)
+		}
+	}
+
+	for _, task := range tasks {
+		if err := <-task.OnComplete(); err != nil {
+			return registered, removed, err
+		}
+	}
+
+	return registered, removed, nil
+}
+
 // Execute executes a user defined function on server and return results.
 // The function operates on a single record.
 // The package name is used to locate the udf file location:
@@ -753,8 +966,15 @@ func (clnt *Client) Execute(policy *WritePolicy, key *Key, packageName string, f
 	}
 
 	record := command.GetRecord()
+	if record == nil {
+		return nil, nil
+	}
 
-	if record == nil || len(record.Bins) == 0 {
+	if err := record.DecodeBins(); err != nil {
+		return nil, err
+	}
+
+	if len(record.Bins) == 0 {
 		return nil, nil
 	}
 
@@ -824,6 +1044,149 @@ func (clnt *Client) ExecuteUDF(policy *QueryPolicy,
 	return NewExecuteTask(clnt.cluster, statement), mergeErrors(errs)
 }
 
+// ExecuteOperations applies the write operations on the records that match
+// the statement filter. Records are not returned to the client.
+// The statement must not have a UDF set.
+// The policy determines the expiration, generation and commit level
+// of the writes, as well as the timeout of the command.
+// This asynchronous server call will return before command is complete.
+// The user can optionally wait for command completion by using the returned
+// ExecuteTask instance.
+//
+// This method is only supported by Aerospike 3 servers.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) ExecuteOperations(policy *WritePolicy,
+	statement *Statement,
+	ops ...*Operation,
+) (*ExecuteTask, error) {
+	policy = clnt.getUsableWritePolicy(policy)
+
+	if len(ops) == 0 {
+		return nil, NewAerospikeError(PARAMETER_ERROR, "ExecuteOperations requires at least one operation.")
+	}
+
+	for _, op := range ops {
+		if op.OpType == READ || op.OpType == CDT_READ {
+			return nil, NewAerospikeError(PARAMETER_ERROR, "ExecuteOperations does not support read operations.")
+		}
+	}
+
+	if statement.functionName != "" {
+		return nil, NewAerospikeError(PARAMETER_ERROR, "ExecuteOperations does not support statements with a UDF.")
+	}
+
+	nodes := clnt.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return nil, NewAerospikeError(SERVER_NOT_AVAILABLE, "ExecuteOperations failed because cluster is empty.")
+	}
+
+	// wait until all migrations are finished
+	if err := clnt.cluster.WaitUntillMigrationIsFinished(policy.Timeout); err != nil {
+		return nil, err
+	}
+
+	// the command must be one-shot to avoid applying the operations twice
+	queryPolicy := NewQueryPolicy()
+	*queryPolicy.BasePolicy = policy.BasePolicy
+	queryPolicy.MaxRetries = 0
+
+	errs := []error{}
+	for i := range nodes {
+		command := newServerOperationsCommand(nodes[i], queryPolicy, policy, statement, ops)
+		if err := command.Execute(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return NewExecuteTask(clnt.cluster, statement), mergeErrors(errs)
+}
+
+//--------------------------------------------------------
+// Job functions (Supported by Aerospike 3 servers only)
+//--------------------------------------------------------
+
+// ListJobs returns the scan or query jobs of all nodes in the cluster.
+// Each node reports its own part of the jobs, so a job which runs on the
+// whole cluster is listed once for every node.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) ListJobs(policy *BasePolicy, jobType JobType) ([]*JobInfo, error) {
+	policy = clnt.getUsablePolicy(policy)
+
+	nodes := clnt.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return nil, NewAerospikeError(SERVER_NOT_AVAILABLE, "ListJobs failed because cluster is empty.")
+	}
+
+	var res []*JobInfo
+	for _, node := range nodes {
+		jobs, err := requestNodeJobs(node, policy.Timeout, jobType)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, jobs...)
+	}
+
+	return res, nil
+}
+
+// GetJobInfo returns the status and progress of the scan or query job
+// with the taskId on each node of the cluster.
+// Nodes which do not know about the job are not included in the result.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) GetJobInfo(policy *BasePolicy, jobType JobType, taskId int64) ([]*JobInfo, error) {
+	policy = clnt.getUsablePolicy(policy)
+
+	nodes := clnt.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return nil, NewAerospikeError(SERVER_NOT_AVAILABLE, "GetJobInfo failed because cluster is empty.")
+	}
+
+	var res []*JobInfo
+	for _, node := range nodes {
+		job, err := requestNodeJob(node, policy.Timeout, jobType, taskId)
+		if err != nil {
+			return nil, err
+		}
+
+		if job != nil {
+			res = append(res, job)
+		}
+	}
+
+	return res, nil
+}
+
+// AbortJob aborts the scan or query job with the taskId on all nodes of the cluster.
+// Nodes on which the job is not running anymore are skipped.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) AbortJob(policy *BasePolicy, jobType JobType, taskId int64) error {
+	policy = clnt.getUsablePolicy(policy)
+
+	nodes := clnt.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return NewAerospikeError(SERVER_NOT_AVAILABLE, "AbortJob failed because cluster is empty.")
+	}
+
+	errs := []error{}
+	for _, node := range nodes {
+		job, err := requestNodeJob(node, policy.Timeout, jobType, taskId)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if job == nil || job.Status != JOB_STATUS_IN_PROGRESS {
+			continue
+		}
+
+		if err := abortNodeJob(node, policy.Timeout, jobType, taskId); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return mergeErrors(errs)
+}
+
 //--------------------------------------------------------
 // Query functions (Supported by Aerospike 3 servers only)
 //--------------------------------------------------------
@@ -853,6 +1216,9 @@ func (clnt *Client) Query(policy *QueryPolicy, statement *Statement) (*Recordset
 	// results channel must be async for performance
 	recSet := newRecordset(policy.RecordQueueSize, len(nodes))
 
+	statement.setTaskId()
+	recSet.taskId = statement.TaskId
+
 	// results channel must be async for performance
 	for _, node := range nodes {
 		// copy policies to avoid race conditions
@@ -883,6 +1249,9 @@ func (clnt *Client) QueryNode(policy *QueryPolicy, node *Node, statement *Statem
 	// results channel must be async for performance
 	recSet := newRecordset(policy.RecordQueueSize, 1)
 
+	statement.setTaskId()
+	recSet.taskId = statement.TaskId
+
 	// copy policies to avoid race conditions
 	newPolicy := *policy
 	command := newQueryRecordCommand(node, &newPolicy, statement, recSet)
@@ -891,25 +1260,136 @@ func (clnt *Client) QueryNode(policy *QueryPolicy, node *Node, statement *Statem
 	return recSet, nil
 }
 
-// // Execute query, apply statement's aggregation function, and return result iterator. The query
-// // executor puts results on a channel in separate goroutines.  The calling goroutine concurrently pops
-// // results off the queue through the result iterator.
-// //
-// // The aggregation function is called on both server and client (reduce).  Therefore,
-// // the Lua script files must also reside on both server and client.
-// // The package name is used to locate the udf file location:
-// // <p>
-// // udf file = <udf dir>/<package name>.lua
-// // <p>
-// // This method is only supported by Aerospike 3 servers.
-// func (clnt *Client) QueryAggregate(
-// 	policy QueryPolicy,
-// 	statement Statement,
-// 	packageName string,
-// 	functionName string,
-// 	functionArgs ...Value,
-// ) (ResultSet, error) {
-// }
+// SetLuaPath sets the directory from which the Lua UDF modules are loaded
+// for the client-side stage of QueryAggregate. The default is "./udf/".
+func SetLuaPath(lpath string) {
+	lua.SetPath(lpath)
+}
+
+// QueryAggregate executes a query, applies the statement's aggregation function
+// and returns a Recordset of the results. The query executor puts the results
+// on the channel from separate goroutines. The caller can concurrently pop the
+// results off the channel through the Recordset.Results() channel.
+// Each result is returned in the "SUCCESS" bin of its record.
+//
+// The aggregation function is called on both server and client (reduce).
+// Therefore, the Lua script file must also reside on both server and client.
+// The package name is used to locate the UDF file on the client:
+//
+// udf file = <lua path>/<package name>.lua
+//
+// This method is only supported by Aerospike 3 servers.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) QueryAggregate(policy *QueryPolicy, statement *Statement, packageName, functionName string, functionArgs ...Value) (*Recordset, error) {
+	policy = clnt.getUsableQueryPolicy(policy)
+
+	nodes := clnt.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return nil, NewAerospikeError(SERVER_NOT_AVAILABLE, "QueryAggregate failed because cluster is empty.")
+	}
+
+	if policy.WaitUntilMigrationsAreOver {
+		// wait until all migrations are finished
+		if err := clnt.cluster.WaitUntillMigrationIsFinished(policy.Timeout); err != nil {
+			return nil, err
+		}
+	}
+
+	// load the module before sending the query to fail early
+	L, err := lua.NewInstance()
+	if err != nil {
+		return nil, NewAerospikeError(UDF_BAD_RESPONSE, err.Error())
+	}
+
+	if err := L.DoFile(lua.ModulePath(packageName)); err != nil {
+		L.Close()
+		return nil, NewAerospikeError(UDF_BAD_RESPONSE, err.Error())
+	}
+
+	statement.SetAggregateFunction(packageName, functionName, functionArgs, true)
+
+	// results channel must be async for performance
+	recSet := newRecordset(policy.RecordQueueSize, len(nodes))
+
+	statement.setTaskId()
+	recSet.taskId = statement.TaskId
+
+	// the results from the nodes are aggregated in the Lua state
+	// before being sent back on the recordset
+	inputChan := make(chan interface{}, policy.RecordQueueSize)
+	outputChan := make(chan interface{}, policy.RecordQueueSize)
+
+	var wg sync.WaitGroup
+	wg.Add(len(nodes))
+	for _, node := range nodes {
+		// copy policies to avoid race conditions
+		newPolicy := *policy
+		command := newQueryAggregateCommand(node, &newPolicy, statement, recSet, inputChan)
+		go func() {
+			defer wg.Done()
+			command.Execute()
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(inputChan)
+	}()
+
+	go func() {
+		defer L.Close()
+		defer close(outputChan)
+
+		args := []ilua.LValue{
+			L.GetGlobal(functionName),
+			ilua.LNumber(2), // client scope
+			lua.NewLuaStream(L, inputChan),
+			lua.NewLuaStream(L, outputChan),
+		}
+		for _, arg := range functionArgs {
+			args = append(args, lua.NewValue(L, arg))
+		}
+
+		err := L.CallByParam(ilua.P{
+			Fn:      L.GetGlobal("apply_stream"),
+			NRet:    1,
+			Protect: true,
+		}, args...)
+
+		if err != nil {
+			select {
+			case recSet.Errors <- NewAerospikeError(UDF_BAD_RESPONSE, err.Error()):
+			case <-recSet.cancelled:
+			}
+
+			// drain the results so that the commands can finish
+			for range inputChan {
+			}
+		}
+	}()
+
+	go func() {
+		cancelled := false
+		for value := range outputChan {
+			if cancelled {
+				continue
+			}
+
+			select {
+			case recSet.Records <- &Record{Bins: BinMap{"SUCCESS": value}}:
+			case <-recSet.cancelled:
+				// keep draining the output so that the aggregation can finish
+				cancelled = true
+			}
+		}
+
+		for range nodes {
+			recSet.signalEnd()
+		}
+	}()
+
+	return recSet, nil
+}
 
 // CreateIndex creates a secondary index.
 // This asynchronous server call will return before the command is complete.
@@ -969,6 +1449,46 @@ func (clnt *Client) CreateIndex(
 	return nil, NewAerospikeError(INDEX_GENERIC, "Create index failed: "+response)
 }
 
+// ListIndexes returns the secondary indexes of the namespace.
+// The index definitions are requested from a random node; use IndexInfo
+// for the state of an index on each node.
+// This method is only supported by Aerospike 3 servers.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) ListIndexes(policy *BasePolicy, namespace string) ([]*IndexInfo, error) {
+	policy = clnt.getUsablePolicy(policy)
+
+	node, err := clnt.cluster.GetRandomNode()
+	if err != nil {
+		return nil, err
+	}
+
+	return requestNodeIndexes(node, policy.Timeout, namespace)
+}
+
+// IndexInfo returns the definition, state, number of entries and build
+// progress of the secondary index on each node of the cluster.
+// This method is only supported by Aerospike 3 servers.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) IndexInfo(policy *BasePolicy, namespace string, indexName string) ([]*IndexInfo, error) {
+	policy = clnt.getUsablePolicy(policy)
+
+	nodes := clnt.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return nil, NewAerospikeError(SERVER_NOT_AVAILABLE, "IndexInfo failed because cluster is empty.")
+	}
+
+	res := make([]*IndexInfo, 0, len(nodes))
+	for _, node := range nodes {
+		info, err := requestNodeIndexInfo(node, policy.Timeout, namespace, indexName)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, info)
+	}
+
+	return res, nil
+}
+
 // DropIndex deletes a secondary index.
 // This method is only supported by Aerospike 3 servers.
 // If the policy is nil, the default relevant policy will be used.
@@ -1013,6 +1533,259 @@ func (clnt *Client) DropIndex(
 	return NewAerospikeError(INDEX_GENERIC, "Drop index failed: "+response)
 }
 
+//-------------------------------------------------------
+// Cluster Metadata
+//-------------------------------------------------------
+
+// Namespaces returns the names of the namespaces on the nodes of the cluster.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) Namespaces(policy *BasePolicy) ([]string, error) {
+	policy = clnt.getUsablePolicy(policy)
+
+	nodes := clnt.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return nil, NewAerospikeError(SERVER_NOT_AVAILABLE, "Namespaces failed because cluster is empty.")
+	}
+
+	var res []string
+	found := make(map[string]struct{})
+	for _, node := range nodes {
+		namespaces, err := requestNodeNamespaces(node, policy.Timeout)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ns := range namespaces {
+			if _, exists := found[ns]; !exists {
+				found[ns] = struct{}{}
+				res = append(res, ns)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// NamespaceInfo returns the replication factor, object count, memory and
+// disk usage and evictions of the namespace, aggregated across the nodes
+// of the cluster.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) NamespaceInfo(policy *BasePolicy, namespace string) (*NamespaceInfo, error) {
+	policy = clnt.getUsablePolicy(policy)
+
+	nodes := clnt.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return nil, NewAerospikeError(SERVER_NOT_AVAILABLE, "NamespaceInfo failed because cluster is empty.")
+	}
+
+	res := &NamespaceInfo{
+		Name:           namespace,
+		NodeAttributes: make(map[string]map[string]string, len(nodes)),
+	}
+
+	for _, node := range nodes {
+		stats, err := requestNodeNamespaceStats(node, policy.Timeout, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		if stats != nil {
+			res.mergeNodeStats(node, stats)
+		}
+	}
+
+	if len(res.NodeAttributes) == 0 {
+		return nil, NewAerospikeError(INVALID_NAMESPACE, "Namespace "+namespace+" not found")
+	}
+
+	return res, nil
+}
+
+// Sets returns the object count, memory usage and stop-writes limit of the
+// sets of the namespace, aggregated across the nodes of the cluster.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) Sets(policy *BasePolicy, namespace string) ([]*SetInfo, error) {
+	policy = clnt.getUsablePolicy(policy)
+
+	nodes := clnt.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return nil, NewAerospikeError(SERVER_NOT_AVAILABLE, "Sets failed because cluster is empty.")
+	}
+
+	var res []*SetInfo
+	sets := make(map[string]*SetInfo)
+	for _, node := range nodes {
+		nodeSets, err := requestNodeSets(node, policy.Timeout, namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, stats := range nodeSets {
+			name, _ := infoValue(stats, "set", "set_name")
+			set, exists := sets[name]
+			if !exists {
+				set = &SetInfo{
+					Namespace:      namespace,
+					Name:           name,
+					NodeAttributes: make(map[string]map[string]string, len(nodes)),
+				}
+				sets[name] = set
+				res = append(res, set)
+			}
+			set.mergeNodeStats(node, stats)
+		}
+	}
+
+	return res, nil
+}
+
+// NodeStatistics returns the statistics of the node.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) NodeStatistics(policy *BasePolicy, node *Node) (*NodeStats, error) {
+	policy = clnt.getUsablePolicy(policy)
+
+	stats, err := requestNodeStats(node, policy.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return newNodeStats(node, stats), nil
+}
+
+//-------------------------------------------------------
+// Server Configuration
+//-------------------------------------------------------
+
+// GetConfig returns the configuration parameters of the context on the node.
+// Use NamespaceConfigContext for the configuration of a namespace.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) GetConfig(policy *BasePolicy, node *Node, context ConfigContext) (map[string]string, error) {
+	policy = clnt.getUsablePolicy(policy)
+
+	return requestNodeConfig(node, policy.Timeout, context)
+}
+
+// SetConfig sets the dynamic configuration parameters of the context on
+// all nodes of the cluster.
+// Names and values containing ';', '=' or ':' are rejected with PARAMETER_ERROR.
+// If setting a parameter fails on a node, the parameters already set are
+// restored to their previous values on all nodes, and the returned error
+// reports the failure, as well as any parameter which could not be restored.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) SetConfig(policy *WritePolicy, context ConfigContext, params map[string]string) error {
+	policy = clnt.getUsableWritePolicy(policy)
+
+	if len(params) == 0 {
+		return NewAerospikeError(PARAMETER_ERROR, "No config parameters to set.")
+	}
+
+	// check all the parameters before changing anything on the nodes
+	for name, value := range params {
+		if err := checkConfigParam(name, value); err != nil {
+			return err
+		}
+	}
+
+	nodes := clnt.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return NewAerospikeError(SERVER_NOT_AVAILABLE, "SetConfig failed because cluster is empty.")
+	}
+
+	// keep the current values to roll back to
+	previous := make([]map[string]string, len(nodes))
+	for i, node := range nodes {
+		config, err := requestNodeConfig(node, policy.Timeout, context)
+		if err != nil {
+			return newNodeError(node, err)
+		}
+		previous[i] = config
+	}
+
+	names := sortedConfigNames(params)
+	applied := make([][]string, len(nodes))
+
+	var errs []error
+	for i, node := range nodes {
+		for _, name := range names {
+			if err := setNodeConfig(node, policy.Timeout, context, name, params[name]); err != nil {
+				errs = append(errs, newNodeError(node, err))
+				break
+			}
+			applied[i] = append(applied[i], name)
+		}
+
+		if len(errs) > 0 {
+			break
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	for i, node := range nodes {
+		for _, name := range applied[i] {
+			value, exists := previous[i][name]
+			if !exists {
+				errs = append(errs, newAerospikeNodeError(node, SERVER_ERROR, "Rolling back "+name+" failed on node "+node.GetName()+": previous value unknown"))
+				continue
+			}
+
+			if err := setNodeConfig(node, policy.Timeout, context, name, value); err != nil {
+				errs = append(errs, newNodeError(node, err))
+			}
+		}
+	}
+
+	return mergeErrors(errs)
+}
+
+//-------------------------------------------------------
+// Truncate (Supported by Aerospike 3.12+ servers only)
+//-------------------------------------------------------
+
+// Truncate removes the records of the set, or of the whole namespace if the
+// set name is empty, which were last updated before beforeTime.
+// If beforeTime is nil, all records are removed.
+// The command is sent to every node, and an error is returned
+// for each node which did not accept it.
+// This method is only supported by Aerospike 3.12+ servers.
+// If the policy is nil, the default relevant policy will be used.
+func (clnt *Client) Truncate(policy *WritePolicy, namespace, set string, beforeTime *time.Time) error {
+	policy = clnt.getUsableWritePolicy(policy)
+
+	nodes := clnt.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return NewAerospikeError(SERVER_NOT_AVAILABLE, "Truncate failed because cluster is empty.")
+	}
+
+	command := "truncate:namespace=" + namespace
+	if len(set) > 0 {
+		command += ";set=" + set
+	}
+
+	if beforeTime != nil {
+		// last update time is in nanoseconds since the Unix epoch
+		command += ";lut=" + strconv.FormatInt(beforeTime.UnixNano(), 10)
+	}
+
+	errs := []error{}
+	for _, node := range nodes {
+		responseMap, err := clnt.sendNodeInfoCommand(policy, node, command)
+		if err != nil {
+			errs = append(errs, newNodeError(node, err))
+			continue
+		}
+
+		response := responseMap[command]
+		if strings.ToUpper(response) != "OK" {
+			errs = append(errs, newAerospikeNodeError(node, SERVER_ERROR, "Truncate failed on node "+node.GetName()+": "+response))
+		}
+	}
+
+	return mergeErrors(errs)
+}
+
 //-------------------------------------------------------
 // User administration
 //-------------------------------------------------------
@@ -1042,7 +1815,8 @@ func (clnt *Client) DropUser(policy *AdminPolicy, user string) error {
 func (clnt *Client) ChangePassword(policy *AdminPolicy, user string, password string) error {
 	policy = clnt.getUsableAdminPolicy(policy)
 
-	if clnt.cluster.user == "" {
+	clusterUser, _ := clnt.cluster.credentials()
+	if clusterUser == "" {
 		return NewAerospikeError(INVALID_USER)
 	}
 
@@ -1052,7 +1826,7 @@ func (clnt *Client) ChangePassword(policy *AdminPolicy, user string, password st
 	}
 	command := newAdminCommand()
 
-	if user == clnt.cluster.user {
+	if user == clusterUser {
 		// Change own password.
 		if err := command.changePassword(clnt.cluster, policy, user, hash); err != nil {
 			return err
@@ -1108,6 +1882,54 @@ func (clnt *Client) QueryUsers(policy *AdminPolicy) ([]*UserRoles, error) {
 	return command.queryUsers(clnt.cluster, policy)
 }
 
+// Create role with the privileges.
+func (clnt *Client) CreateRole(policy *AdminPolicy, roleName string, privileges []Privilege) error {
+	policy = clnt.getUsableAdminPolicy(policy)
+
+	command := newAdminCommand()
+	return command.createRole(clnt.cluster, policy, roleName, privileges)
+}
+
+// Remove role from cluster.
+func (clnt *Client) DropRole(policy *AdminPolicy, roleName string) error {
+	policy = clnt.getUsableAdminPolicy(policy)
+
+	command := newAdminCommand()
+	return command.dropRole(clnt.cluster, policy, roleName)
+}
+
+// Add privileges to role's list of privileges.
+func (clnt *Client) GrantPrivileges(policy *AdminPolicy, roleName string, privileges []Privilege) error {
+	policy = clnt.getUsableAdminPolicy(policy)
+
+	command := newAdminCommand()
+	return command.grantPrivileges(clnt.cluster, policy, roleName, privileges)
+}
+
+// Remove privileges from role's list of privileges.
+func (clnt *Client) RevokePrivileges(policy *AdminPolicy, roleName string, privileges []Privilege) error {
+	policy = clnt.getUsableAdminPolicy(policy)
+
+	command := newAdminCommand()
+	return command.revokePrivileges(clnt.cluster, policy, roleName, privileges)
+}
+
+// Retrieve privileges for a given role.
+func (clnt *Client) QueryRole(policy *AdminPolicy, roleName string) (*RolePrivileges, error) {
+	policy = clnt.getUsableAdminPolicy(policy)
+
+	command := newAdminCommand()
+	return command.queryRole(clnt.cluster, policy, roleName)
+}
+
+// Retrieve all roles and their privileges.
+func (clnt *Client) QueryRoles(policy *AdminPolicy) ([]*RolePrivileges, error) {
+	policy = clnt.getUsableAdminPolicy(policy)
+
+	command := newAdminCommand()
+	return command.queryRoles(clnt.cluster, policy)
+}
+
 //-------------------------------------------------------
 // Internal Methods
 //-------------------------------------------------------
@@ -1118,6 +1940,10 @@ func (clnt *Client) sendInfoCommand(policy *WritePolicy, command string) (map[st
 		return nil, err
 	}
 
+	return clnt.sendNodeInfoCommand(policy, node, command)
+}
+
+func (clnt *Client) sendNodeInfoCommand(policy *WritePolicy, node *Node, command string) (map[string]string, error) {
 	conn, err := node.GetConnection(policy.Timeout)
 	if err != nil {
 		return nil, err
@@ -15,16 +15,59 @@
 package aerospike_test
 
 import (
+	"fmt"
 	"math"
+	"math/big"
 	"time"
 
 	. "github.com/aerospike/aerospike-client-go"
+	. "github.com/aerospike/aerospike-client-go/types"
 	// . "github.com/aerospike/aerospike-client-go/utils/buffer"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
 
+// color marshals itself to its name
+type color int
+
+var colorNames = []string{"red", "green", "blue"}
+
+func (c color) MarshalValue() (interface{}, error) {
+	return colorNames[c], nil
+}
+
+func (c *color) UnmarshalValue(value interface{}) error {
+	for i, name := range colorNames {
+		if name == value {
+			*c = color(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown color %v", value)
+}
+
+// palette writes its colors to a single bin
+type palette struct {
+	colors []color
+}
+
+func (p *palette) MarshalBins() (BinMap, error) {
+	return BinMap{"colors": p.colors}, nil
+}
+
+func (p *palette) UnmarshalBins(bins BinMap) error {
+	p.colors = nil
+	for _, c := range bins["colors"].([]interface{}) {
+		var cl color
+		if err := cl.UnmarshalValue(c); err != nil {
+			return err
+		}
+		p.colors = append(p.colors, cl)
+	}
+	return nil
+}
+
 // ALL tests are isolated by SetName and Key, which are 50 random charachters
 var _ = Describe("Aerospike", func() {
 	initTestVars()
@@ -158,6 +201,9 @@ var _ = Describe("Aerospike", func() {
 			// std lib type
 			Tm  time.Time
 			TmP *time.Time
+
+			BigInt  big.Int
+			BigIntP *big.Int
 		}
 
 		makeTestObject := func() *testObject {
@@ -172,6 +218,7 @@ var _ = Describe("Aerospike", func() {
 			up32 := uint32(14)
 			p64 := int64(16)
 			up64 := uint64(math.MaxUint64)
+			bigInt, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
 			f32p := float32(math.MaxFloat32)
 			f64p := math.MaxFloat64
 			str := "pointer to a string"
@@ -282,6 +329,9 @@ var _ = Describe("Aerospike", func() {
 
 				Tm:  now,
 				TmP: &now,
+
+				BigInt:  *big.NewInt(math.MinInt64),
+				BigIntP: bigInt,
 			}
 		}
 
@@ -300,6 +350,72 @@ var _ = Describe("Aerospike", func() {
 
 			})
 
+			It("must save an object with slices and maps of structs, and read it back identically", func() {
+
+				type Session struct {
+					Count int
+					Start time.Time
+				}
+
+				type Profile struct {
+					Session
+
+					Sessions []Session
+					ByDevice map[string]*Session
+					Last     **Session
+					History  [][]Session
+				}
+
+				start := time.Unix(1500000000, 0)
+				last := &Session{Count: 9, Start: start}
+				testObj := &Profile{
+					Session:  Session{Count: 1},
+					Sessions: []Session{{Count: 2, Start: start}, {Count: 3}},
+					ByDevice: map[string]*Session{"phone": {Count: 4}, "none": nil},
+					Last:     &last,
+					History:  [][]Session{{{Count: 5}}, {}},
+				}
+				err := client.PutObject(nil, key, &testObj)
+				Expect(err).ToNot(HaveOccurred())
+
+				resObj := &Profile{}
+				err = client.GetObject(nil, key, resObj)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resObj).To(Equal(testObj))
+
+			})
+
+			It("must save an object with marshalers and read it back identically", func() {
+
+				type Painting struct {
+					Main   color
+					Others []color
+				}
+
+				testObj := &Painting{Main: color(2), Others: []color{0, 1}}
+				err := client.PutObject(nil, key, testObj)
+				Expect(err).ToNot(HaveOccurred())
+
+				rec, err := client.Get(nil, key)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rec.Bins["Main"]).To(Equal("blue"))
+
+				resObj := &Painting{}
+				err = client.GetObject(nil, key, resObj)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resObj).To(Equal(testObj))
+
+				p := &palette{colors: []color{1, 2}}
+				err = client.PutObject(nil, key, p)
+				Expect(err).ToNot(HaveOccurred())
+
+				resP := &palette{}
+				err = client.GetObject(nil, key, resP)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resP).To(Equal(p))
+
+			})
+
 			It("must save an object and read it back respecting the tags", func() {
 
 				type InnerStruct struct {
@@ -329,6 +445,88 @@ var _ = Describe("Aerospike", func() {
 
 			})
 
+			It("must store the bool fields as integers unless UseBoolBin is set", func() {
+				type BoolStruct struct {
+					Active bool
+				}
+
+				err := client.PutObject(nil, key, &BoolStruct{Active: true})
+				Expect(err).ToNot(HaveOccurred())
+
+				rec, err := client.Get(nil, key)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rec.Bins["Active"]).To(Equal(1))
+
+				resObj := &BoolStruct{}
+				err = client.GetObject(nil, key, resObj)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resObj.Active).To(BeTrue())
+
+				wpolicy := NewWritePolicy(0, 0)
+				wpolicy.UseBoolBin = true
+				err = client.PutObject(wpolicy, key, &BoolStruct{Active: true})
+				Expect(err).ToNot(HaveOccurred())
+
+				rec, err = client.Get(nil, key)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rec.Bins["Active"]).To(Equal(true))
+			})
+
+			It("must honor the tag options and the metadata fields", func() {
+
+				type Account struct {
+					ID       string            `as:"id,key"`
+					Nick     string            `as:"nick,omitempty"`
+					Settings map[string]string `as:"settings,json"`
+					History  []int             `as:"history,msgpack"`
+
+					Generation uint32 `asm:"gen"`
+					TTL        uint32 `asm:"ttl"`
+				}
+
+				testObj := &Account{ID: randString(20), Settings: map[string]string{"lang": "en"}, History: []int{1, 2}, TTL: 300}
+				objKey, err := NewObjectKey(ns, set, testObj)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = client.PutObject(nil, nil, testObj)
+				Expect(err).To(HaveOccurred())
+
+				// the user key is derived from the key field
+				setKey, err := NewKey(ns, set, randString(20))
+				Expect(err).ToNot(HaveOccurred())
+
+				err = client.PutObject(nil, setKey, testObj)
+				Expect(err).ToNot(HaveOccurred())
+
+				exists, err := client.Exists(nil, setKey)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(exists).To(BeFalse())
+
+				rec, err := client.Get(nil, objKey)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rec.Bins).ToNot(HaveKey("nick"))
+				Expect(rec.Bins).ToNot(HaveKey("Generation"))
+				Expect(rec.Bins["settings"]).To(Equal([]byte(`{"lang":"en"}`)))
+
+				resObj := &Account{}
+				err = client.GetObject(nil, objKey, resObj)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(resObj.ID).To(Equal(testObj.ID))
+				Expect(resObj.Settings).To(Equal(testObj.Settings))
+				Expect(resObj.History).To(Equal(testObj.History))
+				Expect(resObj.Generation).To(Equal(uint32(1)))
+				Expect(resObj.TTL).To(BeNumerically("~", 300, 5))
+
+				// the generation is checked against the record's
+				err = client.PutObject(nil, objKey, resObj)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = client.PutObject(nil, objKey, resObj)
+				Expect(err).To(HaveOccurred())
+				Expect(err.(AerospikeError).ResultCode()).To(Equal(GENERATION_ERROR))
+
+			})
+
 		}) // GetHeader context
 
 	})
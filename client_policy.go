@@ -27,6 +27,12 @@ type ClientPolicy struct {
 	// in hashed format. Leave empty for clusters running without restricted access.
 	Password string
 
+	// CredentialsProvider provides the user and password to authenticate with the cluster.
+	// If set, User and Password are ignored. The provider is called when the client is
+	// created, and again when the server rejects the credentials, so that rotated
+	// passwords are picked up without recreating the client.
+	CredentialsProvider CredentialsProvider
+
 	// Initial host connection timeout in milliseconds.  The timeout when opening a connection
 	// to the server host for the first time.
 	Timeout time.Duration //= 1 second
@@ -52,7 +58,7 @@ func NewClientPolicy() *ClientPolicy {
 	}
 }
 
-// RequiresAuthentication returns true if a USer or Password is set for ClientPolicy.
+// RequiresAuthentication returns true if a USer or Password, or a CredentialsProvider is set for ClientPolicy.
 func (cp *ClientPolicy) RequiresAuthentication() bool {
-	return (cp.User != "") || (cp.Password != "")
+	return (cp.User != "") || (cp.Password != "") || cp.CredentialsProvider != nil
 }
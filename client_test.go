@@ -16,9 +16,12 @@ package aerospike_test
 
 import (
 	"bytes"
+	"errors"
 	"math"
+	"math/big"
 	"math/rand"
 	"strings"
+	"time"
 
 	. "github.com/aerospike/aerospike-client-go"
 	. "github.com/aerospike/aerospike-client-go/utils/buffer"
@@ -41,6 +44,72 @@ var _ = Describe("Aerospike", func() {
 			client.Close()
 			Expect(client.IsConnected()).To(BeFalse())
 		})
+
+		It("must return the cluster metadata", func() {
+			client, err := NewClientWithPolicy(clientPolicy, *host, *port)
+			Expect(err).ToNot(HaveOccurred())
+			defer client.Close()
+
+			namespaces, err := client.Namespaces(nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(namespaces).To(ContainElement("test"))
+
+			key, err := NewKey("test", "metadata", randString(50))
+			Expect(err).ToNot(HaveOccurred())
+			err = client.PutBins(nil, key, NewBin("Aerospike", 1))
+			Expect(err).ToNot(HaveOccurred())
+
+			nsInfo, err := client.NamespaceInfo(nil, "test")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(nsInfo.ReplicationFactor).To(BeNumerically(">", 0))
+			Expect(nsInfo.Objects).To(BeNumerically(">", 0))
+			Expect(len(nsInfo.NodeAttributes)).To(Equal(len(client.GetNodes())))
+
+			_, err = client.NamespaceInfo(nil, randString(10))
+			Expect(err).To(HaveOccurred())
+
+			sets, err := client.Sets(nil, "test")
+			Expect(err).ToNot(HaveOccurred())
+			names := []string{}
+			for _, set := range sets {
+				names = append(names, set.Name)
+			}
+			Expect(names).To(ContainElement("metadata"))
+
+			for _, node := range client.GetNodes() {
+				stats, err := client.NodeStatistics(nil, node)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stats.ClusterSize).To(Equal(len(client.GetNodes())))
+			}
+		})
+
+		It("must get and set the server config", func() {
+			client, err := NewClientWithPolicy(clientPolicy, *host, *port)
+			Expect(err).ToNot(HaveOccurred())
+			defer client.Close()
+
+			node := client.GetNodes()[0]
+			config, err := client.GetConfig(nil, node, NamespaceConfigContext("test"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config).To(HaveKey("default-ttl"))
+
+			ttl := config["default-ttl"]
+			err = client.SetConfig(nil, NamespaceConfigContext("test"), map[string]string{"default-ttl": ttl})
+			Expect(err).ToNot(HaveOccurred())
+
+			// the valid parameter must be rolled back when the invalid one fails
+			err = client.SetConfig(nil, NamespaceConfigContext("test"), map[string]string{
+				"default-ttl":        "12345",
+				"xxx-unknown-config": "1",
+			})
+			Expect(err).To(HaveOccurred())
+
+			for _, node := range client.GetNodes() {
+				config, err := client.GetConfig(nil, node, NamespaceConfigContext("test"))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config["default-ttl"]).To(Equal(ttl))
+			}
+		})
 	})
 
 	Describe("Data operations on native types", func() {
@@ -284,6 +353,67 @@ var _ = Describe("Aerospike", func() {
 				})
 			})
 
+			Context("Bins with `float32` and `float64` values", func() {
+				It("must save a key with MULTIPLE bins", func() {
+					bin1 := NewBin("Aerospike1", math.MaxFloat64)
+					bin2 := NewBin("Aerospike2", -math.SmallestNonzeroFloat64)
+					bin3 := NewBin("Aerospike3", float32(math.MaxFloat32))
+					err = client.PutBins(wpolicy, key, bin1, bin2, bin3)
+					Expect(err).ToNot(HaveOccurred())
+
+					rec, err = client.Get(rpolicy, key)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(rec.Bins[bin1.Name]).To(Equal(bin1.Value.GetObject()))
+					Expect(rec.Bins[bin2.Name]).To(Equal(bin2.Value.GetObject()))
+					Expect(rec.Bins[bin3.Name]).To(Equal(float64(float32(math.MaxFloat32))))
+				})
+
+				It("must add to float bins", func() {
+					bin := NewBin("Aerospike", 1.25)
+					err = client.PutBins(wpolicy, key, bin)
+					Expect(err).ToNot(HaveOccurred())
+
+					rec, err = client.Operate(wpolicy, key, AddOp(NewBin("Aerospike", 0.5)), GetOpForBin("Aerospike"))
+					Expect(err).ToNot(HaveOccurred())
+					Expect(rec.Bins[bin.Name]).To(Equal(1.75))
+				})
+			})
+
+			Context("Bins with `bool` values", func() {
+				It("must save a key with MULTIPLE bins", func() {
+					bin1 := NewBin("Aerospike1", true)
+					bin2 := NewBin("Aerospike2", false)
+					err = client.PutBins(wpolicy, key, bin1, bin2)
+					Expect(err).ToNot(HaveOccurred())
+
+					rec, err = client.Get(rpolicy, key)
+					Expect(err).ToNot(HaveOccurred())
+
+					Expect(rec.Bins[bin1.Name]).To(Equal(true))
+					Expect(rec.Bins[bin2.Name]).To(Equal(false))
+				})
+			})
+
+			Context("Bins with `uint64` and `big.Int` values", func() {
+				It("must save a key with MULTIPLE bins", func() {
+					huge, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+					bin1 := NewBin("Aerospike1", uint64(math.MaxUint64))
+					bin2 := NewBin("Aerospike2", huge)
+					bin3 := NewBin("Aerospike3", big.NewInt(42))
+					err = client.PutBins(wpolicy, key, bin1, bin2, bin3)
+					Expect(err).ToNot(HaveOccurred())
+
+					rec, err = client.Get(rpolicy, key)
+					Expect(err).ToNot(HaveOccurred())
+
+					// big integers outside the int64 range are stored as blobs
+					Expect(rec.Bins[bin1.Name]).To(Equal([]byte{0x00, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}))
+					Expect(rec.Bins[bin2.Name]).To(BeAssignableToTypeOf([]byte{}))
+					Expect(rec.Bins[bin3.Name]).To(Equal(42))
+				})
+			})
+
 			Context("Bins with complex types", func() {
 
 				Context("Bins with BLOB type", func() {
@@ -598,6 +728,86 @@ var _ = Describe("Aerospike", func() {
 
 		}) // Exists context
 
+		Context("GetWithHandler operations", func() {
+			bin1 := NewBin("Aerospike1", math.MaxInt64)
+			bin2 := NewBin("Aerospike2", randString(100))
+
+			BeforeEach(func() {
+				err = client.PutBins(wpolicy, key, bin1, bin2)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("must read the raw bins straight from the buffer", func() {
+				bins := map[string][]byte{}
+				types := map[string]int{}
+				found, err := client.GetWithHandler(rpolicy, key, func(binName string, particleType int, raw []byte) error {
+					bins[binName] = append([]byte(nil), raw...)
+					types[binName] = particleType
+					return nil
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(types).To(Equal(map[string]int{bin1.Name: bin1.Value.GetType(), bin2.Name: bin2.Value.GetType()}))
+				Expect(BytesToNumber(bins[bin1.Name], 0, len(bins[bin1.Name]))).To(Equal(bin1.Value.GetObject()))
+				Expect(string(bins[bin2.Name])).To(Equal(bin2.Value.GetObject()))
+			})
+
+			It("must return false for a non-existing key, and the errors of the handler", func() {
+				nxkey, err := NewKey(ns, set, randString(50))
+				Expect(err).ToNot(HaveOccurred())
+
+				found, err := client.GetWithHandler(rpolicy, nxkey, func(binName string, particleType int, raw []byte) error {
+					panic("must not be called")
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+
+				errStop := errors.New("stop")
+				_, err = client.GetWithHandler(rpolicy, key, func(binName string, particleType int, raw []byte) error {
+					return errStop
+				}, bin1.Name)
+				Expect(err).To(Equal(errStop))
+			})
+
+		}) // GetWithHandler context
+
+		Context("Lazy bin decoding", func() {
+			bin1 := NewBin("Aerospike1", math.MaxInt64)
+			bin2 := NewBin("Aerospike2", []interface{}{"a", 1, []byte{1, 2}})
+			bin3 := NewBin("Aerospike3", map[interface{}]interface{}{"a": 1, 2: "b"})
+
+			BeforeEach(func() {
+				err = client.PutBins(wpolicy, key, bin1, bin2, bin3)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("must decode the list and map bins on first access", func() {
+				policy := NewPolicy()
+				policy.LazyBins = true
+
+				rec, err := client.Get(policy, key)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(rec.Bins).To(Equal(BinMap{bin1.Name: bin1.Value.GetObject()}))
+
+				value, err := rec.Bin(bin2.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(value).To(Equal(bin2.Value.GetObject()))
+
+				Expect(rec.DecodeBins()).To(Succeed())
+				Expect(rec.Bins[bin3.Name]).To(Equal(bin3.Value.GetObject()))
+
+				recs, err := client.BatchGet(policy, []*Key{key}, bin3.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(recs[0].Bins).To(BeEmpty())
+
+				value, err = recs[0].Bin(bin3.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(value).To(Equal(bin3.Value.GetObject()))
+			})
+
+		}) // Lazy bin decoding context
+
 		Context("Batch Exists operations", func() {
 			bin := NewBin("Aerospike", rand.Intn(math.MaxInt16))
 			const keyCount = 2048
@@ -926,5 +1136,52 @@ var _ = Describe("Aerospike", func() {
 
 		}) // GetHeader context
 
+		Context("Truncate operations", func() {
+			var tset string
+			var keys []*Key
+
+			BeforeEach(func() {
+				tset = randString(50)
+				keys = nil
+				for i := 0; i < 10; i++ {
+					key, err := NewKey(ns, tset, randString(50))
+					Expect(err).ToNot(HaveOccurred())
+					keys = append(keys, key)
+
+					err = client.PutBins(wpolicy, key, NewBin("Aerospike", i))
+					Expect(err).ToNot(HaveOccurred())
+				}
+			})
+
+			It("must remove all records of the set", func() {
+				err = client.Truncate(nil, ns, tset, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				// truncation is asynchronous on the server
+				time.Sleep(time.Second)
+
+				for _, key := range keys {
+					exists, err := client.Exists(rpolicy, key)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(exists).To(BeFalse())
+				}
+			})
+
+			It("must not remove records updated after beforeTime", func() {
+				beforeTime := time.Now().Add(-time.Hour)
+				err = client.Truncate(nil, ns, tset, &beforeTime)
+				Expect(err).ToNot(HaveOccurred())
+
+				time.Sleep(time.Second)
+
+				for _, key := range keys {
+					exists, err := client.Exists(rpolicy, key)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(exists).To(BeTrue())
+				}
+			})
+
+		}) // Truncate context
+
 	})
 })
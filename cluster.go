@@ -15,6 +15,7 @@
 package aerospike
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"sync"
@@ -55,6 +56,13 @@ type Cluster struct {
 	tendChannel chan struct{}
 	closed      AtomicBool
 
+	// Provides the credentials when they are rejected by the server.
+	credentialsProvider CredentialsProvider
+
+	// Guards the credentials, which change when the password is
+	// changed or the credentials provider returns new credentials.
+	credentialsMutex sync.RWMutex
+
 	// User name in UTF-8 encoded bytes.
 	user string
 
@@ -76,10 +84,17 @@ func NewCluster(policy *ClientPolicy, hosts []*Host) (*Cluster, error) {
 	}
 
 	// setup auth info for cluster
-	var err error
-	if policy.RequiresAuthentication() {
-		newCluster.user = policy.User
-		if newCluster.password, err = hashPassword(policy.Password); err != nil {
+	if policy.CredentialsProvider != nil {
+		newCluster.credentialsProvider = policy.CredentialsProvider
+		user, password, err := policy.CredentialsProvider.Credentials()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := newCluster.setCredentials(user, password); err != nil {
+			return nil, err
+		}
+	} else if policy.RequiresAuthentication() {
+		if _, err := newCluster.setCredentials(policy.User, policy.Password); err != nil {
 			return nil, err
 		}
 	}
@@ -708,8 +723,65 @@ func (clstr *Cluster) WaitUntillMigrationIsFinished(timeout time.Duration) (err
 }
 
 func (clstr *Cluster) changePassword(user string, password []byte) {
+	clstr.credentialsMutex.Lock()
+	defer clstr.credentialsMutex.Unlock()
+
 	// change password ONLY if the user is the same
 	if clstr.user == user {
 		clstr.password = password
 	}
 }
+
+// credentials returns the user name and the hashed password.
+func (clstr *Cluster) credentials() (string, []byte) {
+	clstr.credentialsMutex.RLock()
+	defer clstr.credentialsMutex.RUnlock()
+
+	return clstr.user, clstr.password
+}
+
+// requiresAuthentication returns true if connections need to be authenticated.
+func (clstr *Cluster) requiresAuthentication() bool {
+	user, _ := clstr.credentials()
+	return user != ""
+}
+
+// setCredentials hashes the password and sets the credentials.
+// Returns true if they are different from the current credentials.
+func (clstr *Cluster) setCredentials(user string, password string) (bool, error) {
+	hash, err := hashPassword(password)
+	if err != nil {
+		return false, err
+	}
+
+	clstr.credentialsMutex.Lock()
+	defer clstr.credentialsMutex.Unlock()
+
+	changed := clstr.user != user || !bytes.Equal(clstr.password, hash)
+	clstr.user = user
+	clstr.password = hash
+	return changed, nil
+}
+
+// refreshCredentials gets the credentials from the credentials provider
+// after the server rejected the current ones.
+// Returns true if the credentials have changed and authentication
+// should be retried.
+func (clstr *Cluster) refreshCredentials() bool {
+	if clstr.credentialsProvider == nil {
+		return false
+	}
+
+	user, password, err := clstr.credentialsProvider.Credentials()
+	if err != nil {
+		Logger.Warn("Failed to get the credentials from the provider: %s", err)
+		return false
+	}
+
+	changed, err := clstr.setCredentials(user, password)
+	if err != nil {
+		Logger.Warn("Failed to set the credentials from the provider: %s", err)
+		return false
+	}
+	return changed
+}
@@ -0,0 +1,235 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NamespaceInfo describes a namespace. The statistics are aggregated
+// across the nodes of the cluster.
+type NamespaceInfo struct {
+	Name string
+
+	// ReplicationFactor is the number of copies of each record in the cluster.
+	ReplicationFactor int
+
+	// Objects is the number of records in the namespace, including replicas.
+	Objects int64
+	// MasterObjects is the number of records in the namespace, excluding replicas.
+	MasterObjects int64
+
+	MemoryUsed  int64
+	MemoryTotal int64
+	DiskUsed    int64
+	DiskTotal   int64
+
+	// Evictions is the number of records evicted from the namespace.
+	Evictions int64
+
+	// StopWrites is set if any of the nodes has stopped accepting writes
+	// to the namespace.
+	StopWrites bool
+
+	// NodeAttributes contain the statistics of the namespace on each node,
+	// as reported by the server, indexed by node name.
+	NodeAttributes map[string]map[string]string
+}
+
+// SetInfo describes a set. The statistics are aggregated across the
+// nodes of the cluster.
+type SetInfo struct {
+	Namespace string
+	Name      string
+
+	// Objects is the number of records in the set, including replicas.
+	Objects int64
+
+	// MemoryBytes is the memory used by the data of the set.
+	MemoryBytes int64
+
+	// StopWritesCount is the number of records at which the set stops
+	// accepting writes; 0 means no limit.
+	StopWritesCount int64
+
+	// NodeAttributes contain the statistics of the set on each node,
+	// as reported by the server, indexed by node name.
+	NodeAttributes map[string]map[string]string
+}
+
+// NodeStats contains the statistics of a server node.
+type NodeStats struct {
+	Node *Node
+
+	ClusterSize       int
+	Objects           int64
+	ClientConnections int
+	Uptime            time.Duration
+
+	// Attributes contain all the statistics of the node, as reported by the server.
+	Attributes map[string]string
+}
+
+// parseInfoValues parses a list of 'name=value' pairs separated by separator.
+func parseInfoValues(response string, separator string) map[string]string {
+	values := make(map[string]string)
+	for _, value := range strings.Split(response, separator) {
+		if kv := strings.SplitN(value, "=", 2); len(kv) == 2 {
+			values[kv[0]] = kv[1]
+		}
+	}
+	return values
+}
+
+// infoValue returns the first of the named values which exists.
+// Servers before 3.9 use different names for most statistics.
+func infoValue(values map[string]string, names ...string) (string, bool) {
+	for _, name := range names {
+		if v, exists := values[name]; exists {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+func infoInt64(values map[string]string, names ...string) int64 {
+	v, _ := infoValue(values, names...)
+	res, _ := strconv.ParseInt(v, 10, 64)
+	return res
+}
+
+func infoBool(values map[string]string, names ...string) bool {
+	v, _ := infoValue(values, names...)
+	return v == "true"
+}
+
+// parseNamespaces parses the 'namespaces' info command response.
+func parseNamespaces(response string) []string {
+	var res []string
+	for _, ns := range strings.Split(response, ";") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			res = append(res, ns)
+		}
+	}
+	return res
+}
+
+// mergeNodeStats adds the statistics of a node to the namespace info.
+func (nsi *NamespaceInfo) mergeNodeStats(node *Node, stats map[string]string) {
+	nsi.NodeAttributes[node.GetName()] = stats
+
+	if rf := int(infoInt64(stats, "effective_replication_factor", "replication-factor", "repl-factor")); rf > nsi.ReplicationFactor {
+		nsi.ReplicationFactor = rf
+	}
+
+	nsi.Objects += infoInt64(stats, "objects")
+	nsi.MasterObjects += infoInt64(stats, "master_objects", "master-objects")
+	nsi.MemoryUsed += infoInt64(stats, "memory_used_bytes", "used-bytes-memory")
+	nsi.MemoryTotal += infoInt64(stats, "memory-size")
+	nsi.DiskUsed += infoInt64(stats, "device_used_bytes", "used-bytes-disk")
+	nsi.DiskTotal += infoInt64(stats, "device_total_bytes", "total-bytes-disk")
+	nsi.Evictions += infoInt64(stats, "evicted_objects", "evicted-objects")
+	nsi.StopWrites = nsi.StopWrites || infoBool(stats, "stop_writes", "stop-writes")
+}
+
+// parseSets parses the 'sets/<ns>' info command response. The sets are
+// separated by ';' and each set is a list of 'name=value' statistics
+// separated by ':'.
+func parseSets(response string) []map[string]string {
+	var res []map[string]string
+	for _, set := range strings.Split(response, ";") {
+		if strings.TrimSpace(set) == "" {
+			continue
+		}
+
+		stats := parseInfoValues(set, ":")
+		if _, exists := infoValue(stats, "set", "set_name"); !exists {
+			continue
+		}
+		res = append(res, stats)
+	}
+	return res
+}
+
+// mergeSetStats adds the statistics of a node to the set info.
+func (si *SetInfo) mergeNodeStats(node *Node, stats map[string]string) {
+	si.NodeAttributes[node.GetName()] = stats
+
+	si.Objects += infoInt64(stats, "objects", "n_objects")
+	si.MemoryBytes += infoInt64(stats, "memory_data_bytes", "n-bytes-memory")
+	if swc := infoInt64(stats, "stop-writes-count"); swc > si.StopWritesCount {
+		si.StopWritesCount = swc
+	}
+}
+
+// requestNodeNamespaces returns the names of the namespaces on the node.
+func requestNodeNamespaces(node *Node, timeout time.Duration) ([]string, error) {
+	responseMap, err := requestNodeInfo(node, timeout, "namespaces")
+	if err != nil {
+		return nil, err
+	}
+	return parseNamespaces(responseMap["namespaces"]), nil
+}
+
+// requestNodeNamespaceStats returns the statistics of the namespace on the
+// node, or nil if the namespace does not exist on the node.
+func requestNodeNamespaceStats(node *Node, timeout time.Duration, namespace string) (map[string]string, error) {
+	command := "namespace/" + namespace
+	responseMap, err := requestNodeInfo(node, timeout, command)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responseMap[command]
+	if err := parseInfoError(response); err != nil {
+		return nil, err
+	}
+
+	stats := parseInfoValues(response, ";")
+	if len(stats) == 0 || stats["type"] == "unknown" {
+		return nil, nil
+	}
+	return stats, nil
+}
+
+// requestNodeSets returns the statistics of the sets of the namespace on the node.
+func requestNodeSets(node *Node, timeout time.Duration, namespace string) ([]map[string]string, error) {
+	command := "sets/" + namespace
+	responseMap, err := requestNodeInfo(node, timeout, command)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responseMap[command]
+	if err := parseInfoError(response); err != nil {
+		return nil, err
+	}
+
+	return parseSets(response), nil
+}
+
+// newNodeStats converts the node statistics to NodeStats.
+func newNodeStats(node *Node, stats map[string]string) *NodeStats {
+	return &NodeStats{
+		Node:              node,
+		ClusterSize:       int(infoInt64(stats, "cluster_size")),
+		Objects:           infoInt64(stats, "objects"),
+		ClientConnections: int(infoInt64(stats, "client_connections")),
+		Uptime:            time.Duration(infoInt64(stats, "uptime")) * time.Second,
+		Attributes:        stats,
+	}
+}
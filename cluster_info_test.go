@@ -0,0 +1,85 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster Info Test", func() {
+
+	It("should parse the namespaces command response", func() {
+		Expect(parseNamespaces("test;bar")).To(Equal([]string{"test", "bar"}))
+		Expect(parseNamespaces("")).To(BeEmpty())
+	})
+
+	It("should aggregate the namespace statistics", func() {
+		nsi := &NamespaceInfo{Name: "test", NodeAttributes: map[string]map[string]string{}}
+
+		nsi.mergeNodeStats(&Node{name: "A"}, parseInfoValues("objects=10;master_objects=5;effective_replication_factor=2;memory_used_bytes=100;memory-size=1000;device_used_bytes=200;device_total_bytes=2000;evicted_objects=1;stop_writes=false", ";"))
+		nsi.mergeNodeStats(&Node{name: "B"}, parseInfoValues("objects=20;master-objects=15;repl-factor=2;used-bytes-memory=300;memory-size=1000;used-bytes-disk=400;total-bytes-disk=2000;evicted-objects=2;stop-writes=true", ";"))
+
+		Expect(nsi.ReplicationFactor).To(Equal(2))
+		Expect(nsi.Objects).To(Equal(int64(30)))
+		Expect(nsi.MasterObjects).To(Equal(int64(20)))
+		Expect(nsi.MemoryUsed).To(Equal(int64(400)))
+		Expect(nsi.MemoryTotal).To(Equal(int64(2000)))
+		Expect(nsi.DiskUsed).To(Equal(int64(600)))
+		Expect(nsi.DiskTotal).To(Equal(int64(4000)))
+		Expect(nsi.Evictions).To(Equal(int64(3)))
+		Expect(nsi.StopWrites).To(BeTrue())
+		Expect(len(nsi.NodeAttributes)).To(Equal(2))
+		Expect(nsi.NodeAttributes["A"]["objects"]).To(Equal("10"))
+	})
+
+	It("should parse and aggregate the set statistics", func() {
+		sets := parseSets("ns=test:set=demo:objects=10:memory_data_bytes=100:stop-writes-count=0;" +
+			"ns_name=test:set_name=other:n_objects=3:n-bytes-memory=30:stop-writes-count=1000;" +
+			"ns=test:objects=1")
+		Expect(len(sets)).To(Equal(2))
+
+		si := &SetInfo{Namespace: "test", Name: "other", NodeAttributes: map[string]map[string]string{}}
+		si.mergeNodeStats(&Node{name: "A"}, sets[1])
+		si.mergeNodeStats(&Node{name: "B"}, sets[1])
+
+		Expect(si.Objects).To(Equal(int64(6)))
+		Expect(si.MemoryBytes).To(Equal(int64(60)))
+		Expect(si.StopWritesCount).To(Equal(int64(1000)))
+	})
+
+	It("should parse the sets command response of the server", func() {
+		sets := parseSets("ns=test:set=demo:objects=1000:tombstones=0:memory_data_bytes=38000:truncate_lut=0:stop-writes-count=5000:set-enable-xdr=use-default:disable-eviction=false;" +
+			"ns=test:set=users:objects=12:tombstones=0:memory_data_bytes=456:truncate_lut=0:stop-writes-count=0:set-enable-xdr=use-default:disable-eviction=false;")
+		Expect(len(sets)).To(Equal(2))
+
+		si := &SetInfo{Namespace: "test", Name: "demo", NodeAttributes: map[string]map[string]string{}}
+		si.mergeNodeStats(&Node{name: "A"}, sets[0])
+
+		Expect(si.Objects).To(Equal(int64(1000)))
+		Expect(si.MemoryBytes).To(Equal(int64(38000)))
+		Expect(si.StopWritesCount).To(Equal(int64(5000)))
+		Expect(si.NodeAttributes["A"]["disable-eviction"]).To(Equal("false"))
+	})
+
+	It("should convert the node statistics", func() {
+		stats := newNodeStats(nil, parseInfoValues("cluster_size=3;objects=42;client_connections=7;uptime=60", ";"))
+		Expect(stats.ClusterSize).To(Equal(3))
+		Expect(stats.Objects).To(Equal(int64(42)))
+		Expect(stats.ClientConnections).To(Equal(7))
+		Expect(stats.Uptime.Seconds()).To(Equal(float64(60)))
+	})
+
+})
@@ -50,6 +50,8 @@ const (
 	_INFO2_GENERATION_DUP int = (1 << 4)
 	// Create only. Fail if record already exists.
 	_INFO2_CREATE_ONLY int = (1 << 5)
+	// Return a result for every operation.
+	_INFO2_RESPOND_ALL_OPS int = (1 << 7)
 
 	// This is the last of a multi-part message.
 	_INFO3_LAST int = (1 << 0)
@@ -233,6 +235,7 @@ func (cmd *baseCommand) setOperate(policy *WritePolicy, key *Key, operations []*
 	writeAttr := 0
 	readBin := false
 	readHeader := false
+	respondAllOps := false
 
 	for i := range operations {
 		switch operations[i].OpType {
@@ -249,9 +252,16 @@ func (cmd *baseCommand) setOperate(policy *WritePolicy, key *Key, operations []*
 				readAttr |= _INFO1_READ
 				readHeader = true
 			}
+		case CDT_READ:
+			readAttr |= _INFO1_READ
+			readBin = true
 		default:
 			writeAttr = _INFO2_WRITE
 		}
+
+		if isMapOperation(operations[i]) {
+			respondAllOps = true
+		}
 		cmd.estimateOperationSizeForOperation(operations[i])
 	}
 
@@ -265,12 +275,18 @@ func (cmd *baseCommand) setOperate(policy *WritePolicy, key *Key, operations []*
 		readAttr |= _INFO1_NOBINDATA
 	}
 
-	if writeAttr != 0 {
+	// Map operations do not return a result for every operation
+	// unless the server is asked to.
+	if respondAllOps {
+		writeAttr |= _INFO2_RESPOND_ALL_OPS
+	}
+
+	if writeAttr&_INFO2_WRITE != 0 {
 		cmd.writeHeaderWithPolicy(policy, readAttr, writeAttr, fieldCount, len(operations))
 	} else {
 		cmd.writeHeader(policy.GetBasePolicy(), readAttr, writeAttr, fieldCount, len(operations))
 	}
-	cmd.writeKey(key, policy.SendKey && writeAttr != 0)
+	cmd.writeKey(key, policy.SendKey && writeAttr&_INFO2_WRITE != 0)
 
 	for _, operation := range operations {
 		if err := cmd.writeOperationForOperation(operation); err != nil {
@@ -371,7 +387,7 @@ func (cmd *baseCommand) setBatchGet(policy Policy, batchNamespace *batchNamespac
 	return nil
 }
 
-func (cmd *baseCommand) setScan(policy *ScanPolicy, namespace *string, setName *string, binNames []string) error {
+func (cmd *baseCommand) setScan(policy *ScanPolicy, namespace *string, setName *string, binNames []string, taskId int64) error {
 	cmd.begin()
 	fieldCount := 0
 
@@ -385,10 +401,21 @@ func (cmd *baseCommand) setScan(policy *ScanPolicy, namespace *string, setName *
 		fieldCount++
 	}
 
+	predSize := 0
+	if len(policy.PredExp) > 0 {
+		predSize = estimatePredExpSize(policy.PredExp)
+		cmd.dataOffset += predSize + int(_FIELD_HEADER_SIZE)
+		fieldCount++
+	}
+
 	// Estimate scan options size.
 	cmd.dataOffset += 2 + int(_FIELD_HEADER_SIZE)
 	fieldCount++
 
+	// Estimate taskId size.
+	cmd.dataOffset += 8 + int(_FIELD_HEADER_SIZE)
+	fieldCount++
+
 	if binNames != nil {
 		for i := range binNames {
 			cmd.estimateOperationSizeForBinName(binNames[i])
@@ -417,6 +444,12 @@ func (cmd *baseCommand) setScan(policy *ScanPolicy, namespace *string, setName *
 		cmd.writeFieldString(*setName, TABLE)
 	}
 
+	if len(policy.PredExp) > 0 {
+		if err := cmd.writeFieldPredExp(policy.PredExp, predSize); err != nil {
+			return err
+		}
+	}
+
 	cmd.writeFieldHeader(2, SCAN_OPTIONS)
 	priority := byte(policy.Priority)
 	priority <<= 4
@@ -429,6 +462,10 @@ func (cmd *baseCommand) setScan(policy *ScanPolicy, namespace *string, setName *
 	cmd.dataBuffer[cmd.dataOffset] = byte(policy.ScanPercent)
 	cmd.dataOffset++
 
+	cmd.writeFieldHeader(8, TRAN_ID)
+	Buffer.Int64ToBytes(taskId, cmd.dataBuffer, cmd.dataOffset)
+	cmd.dataOffset += 8
+
 	if binNames != nil {
 		for i := range binNames {
 			cmd.writeOperationForBinName(binNames[i], READ)
@@ -682,6 +719,16 @@ func (cmd *baseCommand) writeFieldBytes(bytes []byte, ftype FieldType) {
 	cmd.dataOffset += len(bytes)
 }
 
+func (cmd *baseCommand) writeFieldPredExp(predExp []PredExp, size int) (err error) {
+	cmd.writeFieldHeader(size, PREDEXP)
+	for _, pred := range predExp {
+		if cmd.dataOffset, err = pred.write(cmd.dataBuffer, cmd.dataOffset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (cmd *baseCommand) writeFieldHeader(size int, ftype FieldType) {
 	Buffer.Int32ToBytes(int32(size+1), cmd.dataBuffer, cmd.dataOffset)
 	cmd.dataOffset += 4
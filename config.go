@@ -0,0 +1,119 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	. "github.com/aerospike/aerospike-client-go/types"
+)
+
+// ConfigContext is the context of server configuration parameters.
+type ConfigContext string
+
+const (
+	// CONFIG_SERVICE is the context of the service parameters of the node,
+	// like the number of threads and the migration settings.
+	CONFIG_SERVICE ConfigContext = "service"
+
+	// CONFIG_NETWORK is the context of the network parameters of the node,
+	// like the service, heartbeat and fabric settings.
+	CONFIG_NETWORK ConfigContext = "network"
+
+	// CONFIG_SECURITY is the context of the security parameters of the node,
+	// like the privilege refresh period and the logging settings.
+	CONFIG_SECURITY ConfigContext = "security"
+
+	// CONFIG_XDR is the context of the cross datacenter replication
+	// parameters of the node.
+	CONFIG_XDR ConfigContext = "xdr"
+)
+
+// NamespaceConfigContext returns the context of the configuration
+// parameters of the namespace.
+func NamespaceConfigContext(namespace string) ConfigContext {
+	return ConfigContext("namespace;id=" + namespace)
+}
+
+// sortedConfigNames returns the names of the parameters in a
+// deterministic order, so that all nodes are configured the same way.
+func sortedConfigNames(params map[string]string) []string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkConfigParam returns an error if the name or the value of the parameter
+// contains a separator of the info protocol, which would change the command.
+func checkConfigParam(name, value string) error {
+	if name == "" {
+		return NewAerospikeError(PARAMETER_ERROR, "Config parameter name is empty.")
+	}
+
+	if strings.ContainsAny(name, ";=:") || strings.ContainsAny(value, ";=:") {
+		return NewAerospikeError(PARAMETER_ERROR, "Config parameter "+name+"="+value+" must not contain ';', '=' or ':'.")
+	}
+	return nil
+}
+
+// requestNodeConfig returns the configuration parameters of the context on the node.
+func requestNodeConfig(node *Node, timeout time.Duration, context ConfigContext) (map[string]string, error) {
+	command := "get-config:context=" + string(context)
+	responseMap, err := requestNodeInfo(node, timeout, command)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responseMap[command]
+	if err := parseInfoError(response); err != nil {
+		return nil, err
+	}
+
+	if strings.HasPrefix(strings.ToLower(response), "error") {
+		return nil, NewAerospikeError(SERVER_ERROR, "Getting "+string(context)+" config failed on node "+node.GetName()+": "+response)
+	}
+
+	return parseInfoValues(response, ";"), nil
+}
+
+// setNodeConfig sets a configuration parameter of the context on the node.
+// The server only accepts one parameter per command.
+func setNodeConfig(node *Node, timeout time.Duration, context ConfigContext, name, value string) error {
+	if err := checkConfigParam(name, value); err != nil {
+		return err
+	}
+
+	command := "set-config:context=" + string(context) + ";" + name + "=" + value
+	responseMap, err := requestNodeInfo(node, timeout, command)
+	if err != nil {
+		return err
+	}
+
+	response := responseMap[command]
+	if strings.ToLower(response) == "ok" {
+		return nil
+	}
+
+	msg := "Setting " + name + " to " + value + " failed on node " + node.GetName() + ": " + response
+	if err := parseInfoError(response); err != nil {
+		return NewAerospikeError(err.(AerospikeError).ResultCode(), msg)
+	}
+	return NewAerospikeError(SERVER_ERROR, msg)
+}
@@ -0,0 +1,47 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	. "github.com/aerospike/aerospike-client-go/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Config Test", func() {
+
+	It("should sort the parameter names", func() {
+		Expect(sortedConfigNames(map[string]string{"b": "1", "c": "2", "a": "3"})).To(Equal([]string{"a", "b", "c"}))
+	})
+
+	It("should reject the parameters which would change the info command", func() {
+		Expect(checkConfigParam("default-ttl", "86400")).ToNot(HaveOccurred())
+		Expect(checkConfigParam("default-ttl", "")).ToNot(HaveOccurred())
+
+		for _, param := range [][2]string{
+			{"", "1"},
+			{"default-ttl;migrate-threads", "1"},
+			{"default-ttl=1", "1"},
+			{"default-ttl", "1;migrate-threads=0"},
+			{"default-ttl", "1:2"},
+		} {
+			err := checkConfigParam(param[0], param[1])
+			Expect(err).To(HaveOccurred())
+			Expect(err.(AerospikeError).ResultCode()).To(Equal(PARAMETER_ERROR))
+		}
+	})
+
+})
@@ -0,0 +1,23 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+// CredentialsProvider provides the credentials to authenticate with the
+// cluster, for example from a secrets manager which rotates the passwords.
+// It must be safe for concurrent use.
+type CredentialsProvider interface {
+	// Credentials returns the user name and the clear-text password.
+	Credentials() (user string, password string, err error)
+}
@@ -0,0 +1,73 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type testCredentialsProvider struct {
+	user, password string
+	err            error
+	calls          int
+}
+
+func (p *testCredentialsProvider) Credentials() (string, string, error) {
+	p.calls++
+	return p.user, p.password, p.err
+}
+
+var _ = Describe("Credentials Provider Test", func() {
+
+	It("should refresh the credentials only when they have changed", func() {
+		provider := &testCredentialsProvider{user: "user", password: "pass1"}
+		cluster := &Cluster{credentialsProvider: provider}
+		_, err := cluster.setCredentials("user", "pass1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(cluster.requiresAuthentication()).To(BeTrue())
+
+		_, hash1 := cluster.credentials()
+		Expect(cluster.refreshCredentials()).To(BeFalse())
+
+		provider.password = "pass2"
+		Expect(cluster.refreshCredentials()).To(BeTrue())
+
+		user, hash2 := cluster.credentials()
+		Expect(user).To(Equal("user"))
+		Expect(hash2).ToNot(Equal(hash1))
+		Expect(provider.calls).To(Equal(2))
+	})
+
+	It("should keep the credentials if the provider fails", func() {
+		provider := &testCredentialsProvider{err: errors.New("unavailable")}
+		cluster := &Cluster{credentialsProvider: provider}
+		_, err := cluster.setCredentials("user", "pass")
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(cluster.refreshCredentials()).To(BeFalse())
+		user, _ := cluster.credentials()
+		Expect(user).To(Equal("user"))
+	})
+
+	It("should not refresh the credentials without a provider", func() {
+		cluster := &Cluster{}
+		Expect(cluster.requiresAuthentication()).To(BeFalse())
+		Expect(cluster.refreshCredentials()).To(BeFalse())
+	})
+
+})
@@ -15,9 +15,6 @@
 package aerospike
 
 import (
-	"strconv"
-	"strings"
-
 	. "github.com/aerospike/aerospike-client-go/types"
 )
 
@@ -38,58 +35,40 @@ func NewExecuteTask(cluster *Cluster, statement *Statement) *ExecuteTask {
 	}
 }
 
-// IsDone queries all nodes for task completion status.
-func (etsk *ExecuteTask) IsDone() (bool, error) {
-	var command string
+// TaskId returns the id of the job on the server.
+func (etsk *ExecuteTask) TaskId() int64 {
+	return etsk.taskId
+}
+
+func (etsk *ExecuteTask) jobType() JobType {
 	if etsk.scan {
-		command = "scan-list"
-	} else {
-		command = "query-list"
+		return JOB_SCAN
 	}
+	return JOB_QUERY
+}
 
+// IsDone queries all nodes for task completion status.
+func (etsk *ExecuteTask) IsDone() (bool, error) {
 	nodes := etsk.cluster.GetNodes()
 	done := false
 
 	for _, node := range nodes {
-		conn, err := node.GetConnection(0)
+		job, err := requestNodeJob(node, _DEFAULT_TIMEOUT, etsk.jobType(), etsk.taskId)
 		if err != nil {
 			return false, err
 		}
-		responseMap, err := RequestInfo(conn, command)
-		if err != nil {
-			return false, err
-		}
-
-		node.PutConnection(conn)
 
-		response := responseMap[command]
-		find := "job_id=" + strconv.FormatInt(etsk.taskId, 10) + ":"
-		index := strings.Index(response, find)
-
-		if index < 0 {
+		if job == nil {
 			done = true
 			continue
 		}
 
-		begin := index + len(find)
-		response = response[begin:]
-		find = "job_status="
-		index = strings.Index(response, find)
-
-		if index < 0 {
-			continue
-		}
-
-		begin = index + len(find)
-		response = response[begin:]
-		end := strings.Index(response, ":")
-		status := response[:end]
-
-		if status == "ABORTED" {
+		switch job.Status {
+		case JOB_STATUS_ABORTED:
 			return false, NewAerospikeError(QUERY_TERMINATED)
-		} else if status == "IN PROGRESS" {
+		case JOB_STATUS_IN_PROGRESS:
 			return false, nil
-		} else if status == "DONE" {
+		case JOB_STATUS_DONE:
 			done = true
 		}
 	}
@@ -97,6 +76,35 @@ func (etsk *ExecuteTask) IsDone() (bool, error) {
 	return done, nil
 }
 
+// Progress queries all nodes for the task progress, and returns the
+// percentage of the task completed on the cluster.
+// Nodes which do not report the task are considered to be done with it.
+func (etsk *ExecuteTask) Progress() (int, error) {
+	nodes := etsk.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return 0, NewAerospikeError(SERVER_NOT_AVAILABLE, "Progress failed because cluster is empty.")
+	}
+
+	total := 0
+	for _, node := range nodes {
+		job, err := requestNodeJob(node, _DEFAULT_TIMEOUT, etsk.jobType(), etsk.taskId)
+		if err != nil {
+			return 0, err
+		}
+
+		switch {
+		case job == nil, job.Status == JOB_STATUS_DONE:
+			total += 100
+		case job.Status == JOB_STATUS_ABORTED:
+			return 0, NewAerospikeError(QUERY_TERMINATED)
+		default:
+			total += job.Progress
+		}
+	}
+
+	return total / len(nodes), nil
+}
+
 // OnComplete returns a channel which will be closed when the task is
 // completed.
 // If an error is encountered while performing the task, an error
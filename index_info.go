@@ -0,0 +1,199 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/aerospike/aerospike-client-go/types"
+)
+
+// IndexInfo describes a secondary index, as reported by a server node.
+type IndexInfo struct {
+	// Node is the node which reported the index.
+	Node *Node
+
+	Namespace string
+	SetName   string
+	Name      string
+	BinName   string
+	Type      IndexType
+
+	// State is the state of the index on the node:
+	// "RW" when the index is ready, "WO" while it is being built.
+	State string
+
+	// Entries is the number of entries of the index on the node.
+	// Only reported by Client.IndexInfo.
+	Entries int64
+
+	// LoadPercent is the percentage of the index built on the node.
+	// Only reported by Client.IndexInfo.
+	LoadPercent int
+
+	// Attributes contain all the attributes of the index, as reported by the server.
+	Attributes map[string]string
+}
+
+// parseInfoError returns the error of a failed info command.
+// The errors are in the form of 'FAIL:<code>:<message>' or 'ERROR:<code>:<message>'.
+func parseInfoError(response string) error {
+	if !strings.HasPrefix(response, "FAIL") && !strings.HasPrefix(response, "ERROR") {
+		return nil
+	}
+
+	parts := strings.SplitN(response, ":", 3)
+	if len(parts) > 1 {
+		if code, err := strconv.Atoi(parts[1]); err == nil {
+			if len(parts) > 2 {
+				return NewAerospikeError(ResultCode(code), parts[2])
+			}
+			return NewAerospikeError(ResultCode(code))
+		}
+	}
+	return NewAerospikeError(SERVER_ERROR, response)
+}
+
+// parseIndexes parses the 'sindex' info command response. The indexes are
+// separated by ';' and each index is a list of 'name=value' attributes
+// separated by ':'.
+func parseIndexes(node *Node, response string) []*IndexInfo {
+	var res []*IndexInfo
+
+	for _, index := range strings.Split(response, ";") {
+		if strings.TrimSpace(index) == "" {
+			continue
+		}
+
+		attrs := make(map[string]string)
+		for _, attr := range strings.Split(index, ":") {
+			if kv := strings.SplitN(attr, "=", 2); len(kv) == 2 {
+				attrs[kv[0]] = kv[1]
+			}
+		}
+
+		if attrs["indexname"] == "" {
+			continue
+		}
+
+		binName := attrs["bin"]
+		if binName == "" {
+			binName = attrs["bins"]
+		}
+
+		setName := attrs["set"]
+		if setName == "NULL" {
+			setName = ""
+		}
+
+		res = append(res, &IndexInfo{
+			Node:       node,
+			Namespace:  attrs["ns"],
+			SetName:    setName,
+			Name:       attrs["indexname"],
+			BinName:    binName,
+			Type:       IndexType(strings.ToUpper(attrs["type"])),
+			State:      attrs["state"],
+			Attributes: attrs,
+		})
+	}
+
+	return res
+}
+
+// parseIndexStats parses the 'sindex/<ns>/<name>' info command response,
+// which is a list of 'name=value' statistics separated by ';'.
+func parseIndexStats(response string) map[string]string {
+	return parseInfoValues(response, ";")
+}
+
+// requestNodeIndexes returns the indexes of the namespace on the node.
+func requestNodeIndexes(node *Node, timeout time.Duration, namespace string) ([]*IndexInfo, error) {
+	command := "sindex/" + namespace
+	responseMap, err := requestNodeInfo(node, timeout, command)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responseMap[command]
+	if err := parseInfoError(response); err != nil {
+		return nil, err
+	}
+
+	return parseIndexes(node, response), nil
+}
+
+// requestNodeIndexStats returns the statistics of the index on the node.
+func requestNodeIndexStats(node *Node, timeout time.Duration, namespace, indexName string) (map[string]string, error) {
+	command := "sindex/" + namespace + "/" + indexName
+	responseMap, err := requestNodeInfo(node, timeout, command)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responseMap[command]
+	if err := parseInfoError(response); err != nil {
+		return nil, err
+	}
+
+	return parseIndexStats(response), nil
+}
+
+// requestNodeIndexInfo returns the definition and the statistics of the index on the node.
+func requestNodeIndexInfo(node *Node, timeout time.Duration, namespace, indexName string) (*IndexInfo, error) {
+	indexes, err := requestNodeIndexes(node, timeout, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *IndexInfo
+	for _, index := range indexes {
+		if index.Name == indexName {
+			res = index
+			break
+		}
+	}
+
+	if res == nil {
+		return nil, NewAerospikeError(INDEX_NOTFOUND, "Index "+indexName+" not found on node "+node.GetName())
+	}
+
+	stats, err := requestNodeIndexStats(node, timeout, namespace, indexName)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range stats {
+		if _, exists := res.Attributes[k]; !exists {
+			res.Attributes[k] = v
+		}
+	}
+
+	if entries, exists := stats["entries"]; exists {
+		res.Entries, _ = strconv.ParseInt(entries, 10, 64)
+	} else if keys, exists := stats["keys"]; exists {
+		res.Entries, _ = strconv.ParseInt(keys, 10, 64)
+	}
+
+	if pct, exists := stats["load_pct"]; exists {
+		res.LoadPercent, _ = strconv.Atoi(pct)
+	} else if res.State == "RW" {
+		res.LoadPercent = 100
+	}
+
+	return res, nil
+}
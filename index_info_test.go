@@ -0,0 +1,102 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"sync"
+
+	. "github.com/aerospike/aerospike-client-go/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Index Info Test", func() {
+
+	It("should parse the sindex command response", func() {
+		response := "ns=test:set=demo:indexname=idx_age:num_bins=1:bins=age:type=NUMERIC:sync_state=synced:state=RW;" +
+			"ns=test:indexname=idx_name:set=NULL:bin=name:type=string:indextype=default:context=NULL:state=WO;" +
+			"ns=test:set=demo:num_bins=1"
+
+		indexes := parseIndexes(nil, response)
+		Expect(len(indexes)).To(Equal(2))
+
+		Expect(indexes[0].Namespace).To(Equal("test"))
+		Expect(indexes[0].SetName).To(Equal("demo"))
+		Expect(indexes[0].Name).To(Equal("idx_age"))
+		Expect(indexes[0].BinName).To(Equal("age"))
+		Expect(indexes[0].Type).To(Equal(NUMERIC))
+		Expect(indexes[0].State).To(Equal("RW"))
+		Expect(indexes[0].Attributes["sync_state"]).To(Equal("synced"))
+
+		Expect(indexes[1].SetName).To(Equal(""))
+		Expect(indexes[1].Name).To(Equal("idx_name"))
+		Expect(indexes[1].BinName).To(Equal("name"))
+		Expect(indexes[1].Type).To(Equal(STRING))
+		Expect(indexes[1].State).To(Equal("WO"))
+	})
+
+	It("should parse the index statistics", func() {
+		stats := parseIndexStats("keys=10;entries=1000;ibtr_memory_used=18688;load_pct=42;loadtime=0")
+		Expect(stats["entries"]).To(Equal("1000"))
+		Expect(stats["load_pct"]).To(Equal("42"))
+	})
+
+	It("should return the load percentage, and the failed builds", func() {
+		Expect(indexLoadPercent(parseIndexStats("keys=10;load_pct=42;state=WO"))).To(Equal(42))
+		Expect(indexLoadPercent(parseIndexStats("keys=10;state=WO"))).To(Equal(0))
+		Expect(indexLoadPercent(parseIndexStats("keys=10;state=RW"))).To(Equal(100))
+		Expect(indexLoadPercent(parseIndexStats("keys=10"))).To(Equal(100))
+
+		_, err := indexLoadPercent(parseIndexStats("keys=10;load_pct=42;state=FAILED"))
+		Expect(err).To(HaveOccurred())
+		Expect(err.(AerospikeError).ResultCode()).To(Equal(INDEX_GENERIC))
+	})
+
+	It("should retry the polls which do not find the index a limited number of times per node", func() {
+		tski := NewIndexTask(nil, "test", "idx")
+		nodeA, nodeB := &Node{name: "A"}, &Node{name: "B"}
+
+		var wg sync.WaitGroup
+		wg.Add(_INDEX_NOT_FOUND_RETRIES)
+		for i := 0; i < _INDEX_NOT_FOUND_RETRIES; i++ {
+			go func() {
+				defer wg.Done()
+				Expect(tski.retryNotFound(nodeA)).To(BeTrue())
+			}()
+		}
+		wg.Wait()
+
+		Expect(tski.retryNotFound(nodeA)).To(BeFalse())
+		Expect(tski.retryNotFound(nodeB)).To(BeTrue())
+	})
+
+	It("should parse the info command errors", func() {
+		Expect(parseInfoError("keys=10;entries=1000")).ToNot(HaveOccurred())
+		Expect(parseInfoError("OK")).ToNot(HaveOccurred())
+
+		err := parseInfoError("FAIL:201:NO INDEX")
+		Expect(err).To(HaveOccurred())
+		Expect(err.(AerospikeError).ResultCode()).To(Equal(INDEX_NOTFOUND))
+		Expect(err.Error()).To(Equal("NO INDEX"))
+
+		err = parseInfoError("ERROR:4")
+		Expect(err.(AerospikeError).ResultCode()).To(Equal(PARAMETER_ERROR))
+
+		err = parseInfoError("ERROR::bad command")
+		Expect(err.(AerospikeError).ResultCode()).To(Equal(SERVER_ERROR))
+	})
+
+})
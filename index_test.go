@@ -50,6 +50,7 @@ var _ = Describe("Index operations test", func() {
 			client, err = NewClientWithPolicy(clientPolicy, *host, *port)
 			Expect(err).ToNot(HaveOccurred())
 			key, err = NewKey(ns, set, randString(50))
+			_ = key
 			Expect(err).ToNot(HaveOccurred())
 
 			for i := 0; i < keyCount; i++ {
@@ -115,5 +116,56 @@ var _ = Describe("Index operations test", func() {
 
 		})
 
+		Context("Index introspection", func() {
+
+			It("must list the Indexes and report their state on each node", func() {
+				idxTask, err := client.CreateIndex(wpolicy, ns, set, set+bin1.Name, bin1.Name, NUMERIC)
+				Expect(err).ToNot(HaveOccurred())
+				defer client.DropIndex(wpolicy, ns, set, set+bin1.Name)
+
+				// wait until index is created
+				Expect(<-idxTask.OnComplete()).ToNot(HaveOccurred())
+
+				progress, err := idxTask.Progress()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(progress).To(Equal(100))
+
+				indexes, err := client.ListIndexes(nil, ns)
+				Expect(err).ToNot(HaveOccurred())
+
+				found := false
+				for _, index := range indexes {
+					if index.Name == set+bin1.Name {
+						found = true
+						Expect(index.Namespace).To(Equal(ns))
+						Expect(index.SetName).To(Equal(set))
+						Expect(index.BinName).To(Equal(bin1.Name))
+						Expect(index.Type).To(Equal(NUMERIC))
+					}
+				}
+				Expect(found).To(BeTrue())
+
+				infos, err := client.IndexInfo(nil, ns, set+bin1.Name)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(infos)).To(Equal(len(client.GetNodes())))
+
+				entries := int64(0)
+				for _, info := range infos {
+					Expect(info.Node).ToNot(BeNil())
+					Expect(info.BinName).To(Equal(bin1.Name))
+					Expect(info.State).To(Equal("RW"))
+					Expect(info.LoadPercent).To(Equal(100))
+					entries += info.Entries
+				}
+				Expect(entries).To(BeNumerically(">=", keyCount))
+			})
+
+			It("must return an error for a non-existing Index", func() {
+				_, err := client.IndexInfo(nil, ns, randString(10))
+				Expect(err).To(HaveOccurred())
+			})
+
+		})
+
 	})
 })
@@ -36,7 +36,13 @@ type info struct {
 
 // RequestNodeInfo gets info values by name from the specified database server node.
 func RequestNodeInfo(node *Node, name ...string) (map[string]string, error) {
-	conn, err := node.GetConnection(_DEFAULT_TIMEOUT)
+	return requestNodeInfo(node, _DEFAULT_TIMEOUT, name...)
+}
+
+// requestNodeInfo gets info values by name from the node, using the timeout
+// for the connection.
+func requestNodeInfo(node *Node, timeout time.Duration, name ...string) (map[string]string, error) {
+	conn, err := node.GetConnection(timeout)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +58,13 @@ func RequestNodeInfo(node *Node, name ...string) (map[string]string, error) {
 
 // RequestNodeStats returns statistics for the specified node as a map
 func RequestNodeStats(node *Node) (map[string]string, error) {
-	infoMap, err := RequestNodeInfo(node, "statistics")
+	return requestNodeStats(node, _DEFAULT_TIMEOUT)
+}
+
+// requestNodeStats returns statistics for the node as a map, using the
+// timeout for the connection.
+func requestNodeStats(node *Node, timeout time.Duration) (map[string]string, error) {
+	infoMap, err := requestNodeInfo(node, timeout, "statistics")
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,259 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lua embeds a Lua interpreter in the client to run the client-side
+// stages of stream UDF aggregations.
+package lua
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"sync"
+
+	. "github.com/aerospike/aerospike-client-go/logger"
+	lua "github.com/yuin/gopher-lua"
+)
+
+var (
+	luaPath      = "./udf/"
+	luaPathMutex sync.RWMutex
+)
+
+// SetPath sets the directory from which the Lua UDF modules are loaded.
+func SetPath(lpath string) {
+	luaPathMutex.Lock()
+	luaPath = lpath
+	luaPathMutex.Unlock()
+}
+
+// Path returns the directory from which the Lua UDF modules are loaded.
+func Path() string {
+	luaPathMutex.RLock()
+	res := luaPath
+	luaPathMutex.RUnlock()
+	return res
+}
+
+// ModulePath returns the full path to the module file for the package.
+func ModulePath(packageName string) string {
+	return filepath.Join(Path(), packageName+".lua")
+}
+
+// NewInstance creates a new Lua state with the aerospike runtime
+// (map, list, stream and the stream operations) loaded.
+func NewInstance() (*lua.LState, error) {
+	L := lua.NewState()
+
+	registerLuaMapType(L)
+	registerLuaListType(L)
+	registerLuaStreamType(L)
+	registerLuaLogFunctions(L)
+
+	// let the UDF modules require the other modules in the same directory
+	pkg := L.GetGlobal("package")
+	L.SetField(pkg, "path", lua.LString(filepath.Join(Path(), "?.lua")+";"+L.GetField(pkg, "path").String()))
+
+	if err := L.DoString(luaStreamOps); err != nil {
+		L.Close()
+		return nil, err
+	}
+
+	if err := L.DoString(luaAerospike); err != nil {
+		L.Close()
+		return nil, err
+	}
+
+	return L, nil
+}
+
+func registerLuaLogFunctions(L *lua.LState) {
+	logAt := func(logFunc func(format string, v ...interface{})) lua.LGFunction {
+		return func(L *lua.LState) int {
+			msg := ""
+			for i := 1; i <= L.GetTop(); i++ {
+				if i > 1 {
+					msg += " "
+				}
+				msg += L.ToStringMeta(L.Get(i)).String()
+			}
+			logFunc("%s", msg)
+			return 0
+		}
+	}
+
+	L.SetGlobal("trace", L.NewFunction(logAt(Logger.Debug)))
+	L.SetGlobal("debug", L.NewFunction(logAt(Logger.Debug)))
+	L.SetGlobal("info", L.NewFunction(logAt(Logger.Info)))
+	L.SetGlobal("warn", L.NewFunction(logAt(Logger.Warn)))
+}
+
+// NewValue converts a Go value to its Lua representation.
+// Slices and maps are converted to aerospike list and map objects.
+func NewValue(L *lua.LState, value interface{}) lua.LValue {
+	return toLValue(L, internalize(value))
+}
+
+// LValueToInterface converts a Lua value back to its Go representation.
+// Lua numbers with integral values are returned as int, the rest as float64.
+func LValueToInterface(val lua.LValue) interface{} {
+	return export(fromLValue(val))
+}
+
+// objectValue is implemented by the Value types of the client.
+type objectValue interface {
+	GetObject() interface{}
+}
+
+// internalize converts Go slices and maps into the list and map
+// containers used inside the Lua state, so that nested values can be
+// modified in place.
+func internalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case objectValue:
+		return internalize(v.GetObject())
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i := range v {
+			l[i] = internalize(v[i])
+		}
+		return &luaList{l: l}
+	case map[interface{}]interface{}:
+		m := make(map[interface{}]interface{}, len(v))
+		for k, e := range v {
+			m[internalize(k)] = internalize(e)
+		}
+		return &luaMap{m: m}
+	case int8:
+		return int(v)
+	case int16:
+		return int(v)
+	case int32:
+		return int(v)
+	case uint8:
+		return int(v)
+	case uint16:
+		return int(v)
+	case uint32:
+		return int(v)
+	case float32:
+		return float64(v)
+	case []byte:
+		return string(v)
+	}
+
+	// other slice and map types, like []Value
+	switch rv := reflect.ValueOf(value); rv.Kind() {
+	case reflect.Slice:
+		l := make([]interface{}, rv.Len())
+		for i := range l {
+			l[i] = internalize(rv.Index(i).Interface())
+		}
+		return &luaList{l: l}
+	case reflect.Map:
+		m := make(map[interface{}]interface{}, rv.Len())
+		for _, k := range rv.MapKeys() {
+			m[internalize(k.Interface())] = internalize(rv.MapIndex(k).Interface())
+		}
+		return &luaMap{m: m}
+	}
+	return value
+}
+
+// export converts the containers used inside the Lua state back to Go values.
+func export(value interface{}) interface{} {
+	switch v := value.(type) {
+	case *luaList:
+		l := make([]interface{}, len(v.l))
+		for i := range v.l {
+			l[i] = export(v.l[i])
+		}
+		return l
+	case *luaMap:
+		m := make(map[interface{}]interface{}, len(v.m))
+		for k, e := range v.m {
+			m[export(k)] = export(e)
+		}
+		return m
+	}
+	return value
+}
+
+func toLValue(L *lua.LState, value interface{}) lua.LValue {
+	switch v := value.(type) {
+	case nil:
+		return lua.LNil
+	case bool:
+		return lua.LBool(v)
+	case int:
+		return lua.LNumber(v)
+	case int64:
+		return lua.LNumber(v)
+	case uint:
+		return lua.LNumber(v)
+	case uint64:
+		return lua.LNumber(v)
+	case float64:
+		return lua.LNumber(v)
+	case string:
+		return lua.LString(v)
+	case *luaList:
+		return newLuaListUserData(L, v)
+	case *luaMap:
+		return newLuaMapUserData(L, v)
+	case lua.LValue:
+		return v
+	}
+
+	return lua.LString(fmt.Sprintf("%v", value))
+}
+
+func fromLValue(val lua.LValue) interface{} {
+	switch v := val.(type) {
+	case *lua.LNilType:
+		return nil
+	case lua.LBool:
+		return bool(v)
+	case lua.LNumber:
+		if f := float64(v); f == float64(int64(f)) {
+			return int(int64(f))
+		}
+		return float64(v)
+	case lua.LString:
+		return string(v)
+	case *lua.LUserData:
+		switch ud := v.Value.(type) {
+		case *luaList, *luaMap:
+			return ud
+		}
+		return v.Value
+	case *lua.LTable:
+		// plain Lua tables are treated as lists if they have an array part
+		if v.MaxN() > 0 {
+			l := make([]interface{}, 0, v.MaxN())
+			for i := 1; i <= v.MaxN(); i++ {
+				l = append(l, fromLValue(v.RawGetInt(i)))
+			}
+			return &luaList{l: l}
+		}
+
+		m := make(map[interface{}]interface{})
+		v.ForEach(func(k, e lua.LValue) {
+			m[fromLValue(k)] = fromLValue(e)
+		})
+		return &luaMap{m: m}
+	}
+
+	return val.String()
+}
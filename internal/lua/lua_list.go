@@ -0,0 +1,253 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lua
+
+import (
+	"fmt"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+const luaListTypeName = "LuaList"
+
+// luaList is the aerospike list type inside the Lua state.
+// As with Lua tables, list indexes are 1-based.
+type luaList struct {
+	l []interface{}
+}
+
+var luaListFunctions = map[string]lua.LGFunction{
+	"create":   luaListCreate,
+	"size":     luaListSize,
+	"append":   luaListAppend,
+	"prepend":  luaListPrepend,
+	"insert":   luaListInsert,
+	"remove":   luaListRemove,
+	"take":     luaListTake,
+	"drop":     luaListDrop,
+	"trim":     luaListTrim,
+	"clone":    luaListClone,
+	"concat":   luaListConcat,
+	"merge":    luaListMerge,
+	"iterator": luaListIterator,
+}
+
+func registerLuaListType(L *lua.LState) {
+	mt := L.NewTypeMetatable(luaListTypeName)
+	L.SetField(mt, "__index", L.NewFunction(luaListIndex))
+	L.SetField(mt, "__newindex", L.NewFunction(luaListNewIndex))
+	L.SetField(mt, "__len", L.NewFunction(luaListSize))
+	L.SetField(mt, "__tostring", L.NewFunction(luaListToString))
+
+	// the module is callable, so both list() and list{...} construct a list
+	mod := L.SetFuncs(L.NewTable(), luaListFunctions)
+	modMt := L.NewTable()
+	L.SetField(modMt, "__call", L.NewFunction(luaListCall))
+	L.SetMetatable(mod, modMt)
+	L.SetGlobal("list", mod)
+}
+
+func newLuaListUserData(L *lua.LState, l *luaList) *lua.LUserData {
+	ud := L.NewUserData()
+	ud.Value = l
+	L.SetMetatable(ud, L.GetTypeMetatable(luaListTypeName))
+	return ud
+}
+
+func checkLuaList(L *lua.LState, n int) *luaList {
+	ud := L.CheckUserData(n)
+	if l, ok := ud.Value.(*luaList); ok {
+		return l
+	}
+	L.ArgError(n, "list expected")
+	return nil
+}
+
+func luaListFromTable(L *lua.LState, n int) *luaList {
+	l := &luaList{}
+	if tbl, ok := L.Get(n).(*lua.LTable); ok {
+		l.l = make([]interface{}, 0, tbl.MaxN())
+		for i := 1; i <= tbl.MaxN(); i++ {
+			l.l = append(l.l, fromLValue(tbl.RawGetInt(i)))
+		}
+	}
+	return l
+}
+
+// list(...) is called on the module table, so the arguments start at 2.
+func luaListCall(L *lua.LState) int {
+	L.Push(newLuaListUserData(L, luaListFromTable(L, 2)))
+	return 1
+}
+
+func luaListCreate(L *lua.LState) int {
+	L.Push(newLuaListUserData(L, luaListFromTable(L, 1)))
+	return 1
+}
+
+func luaListIndex(L *lua.LState) int {
+	l := checkLuaList(L, 1)
+	i := L.CheckInt(2)
+	if i < 1 || i > len(l.l) {
+		L.Push(lua.LNil)
+		return 1
+	}
+	L.Push(toLValue(L, l.l[i-1]))
+	return 1
+}
+
+// Setting the index right after the last element appends to the list.
+func luaListNewIndex(L *lua.LState) int {
+	l := checkLuaList(L, 1)
+	i := L.CheckInt(2)
+	switch {
+	case i >= 1 && i <= len(l.l):
+		l.l[i-1] = fromLValue(L.Get(3))
+	case i == len(l.l)+1:
+		l.l = append(l.l, fromLValue(L.Get(3)))
+	default:
+		L.ArgError(2, fmt.Sprintf("index out of range: %d", i))
+	}
+	return 0
+}
+
+func luaListSize(L *lua.LState) int {
+	l := checkLuaList(L, 1)
+	L.Push(lua.LNumber(len(l.l)))
+	return 1
+}
+
+func luaListToString(L *lua.LState) int {
+	l := checkLuaList(L, 1)
+	L.Push(lua.LString(fmt.Sprintf("%v", export(l))))
+	return 1
+}
+
+func luaListAppend(L *lua.LState) int {
+	l := checkLuaList(L, 1)
+	l.l = append(l.l, fromLValue(L.Get(2)))
+	return 0
+}
+
+func luaListPrepend(L *lua.LState) int {
+	l := checkLuaList(L, 1)
+	l.l = append([]interface{}{fromLValue(L.Get(2))}, l.l...)
+	return 0
+}
+
+// insert(l, i, v) inserts the value before the element at index i.
+func luaListInsert(L *lua.LState) int {
+	l := checkLuaList(L, 1)
+	i := L.CheckInt(2)
+	if i < 1 || i > len(l.l)+1 {
+		L.ArgError(2, fmt.Sprintf("index out of range: %d", i))
+	}
+
+	l.l = append(l.l, nil)
+	copy(l.l[i:], l.l[i-1:])
+	l.l[i-1] = fromLValue(L.Get(3))
+	return 0
+}
+
+func luaListRemove(L *lua.LState) int {
+	l := checkLuaList(L, 1)
+	i := L.CheckInt(2)
+	if i >= 1 && i <= len(l.l) {
+		l.l = append(l.l[:i-1], l.l[i:]...)
+	}
+	return 0
+}
+
+func (l *luaList) slice(from, to int) *luaList {
+	if from < 0 {
+		from = 0
+	}
+	if to > len(l.l) {
+		to = len(l.l)
+	}
+	if from > to {
+		from = to
+	}
+
+	res := make([]interface{}, to-from)
+	copy(res, l.l[from:to])
+	return &luaList{l: res}
+}
+
+// take(l, n) returns a new list with the first n elements.
+func luaListTake(L *lua.LState) int {
+	l := checkLuaList(L, 1)
+	L.Push(newLuaListUserData(L, l.slice(0, L.CheckInt(2))))
+	return 1
+}
+
+// drop(l, n) returns a new list without the first n elements.
+func luaListDrop(L *lua.LState) int {
+	l := checkLuaList(L, 1)
+	L.Push(newLuaListUserData(L, l.slice(L.CheckInt(2), len(l.l))))
+	return 1
+}
+
+// trim(l, i) removes the elements starting at index i.
+func luaListTrim(L *lua.LState) int {
+	l := checkLuaList(L, 1)
+	i := L.CheckInt(2)
+	if i >= 1 && i <= len(l.l) {
+		l.l = l.l[:i-1]
+	}
+	return 0
+}
+
+func luaListClone(L *lua.LState) int {
+	l := checkLuaList(L, 1)
+	L.Push(newLuaListUserData(L, l.slice(0, len(l.l))))
+	return 1
+}
+
+// concat(l1, l2) appends the elements of l2 to l1.
+func luaListConcat(L *lua.LState) int {
+	l1 := checkLuaList(L, 1)
+	l2 := checkLuaList(L, 2)
+	l1.l = append(l1.l, l2.l...)
+	return 0
+}
+
+// merge(l1, l2) returns a new list with the elements of both lists.
+func luaListMerge(L *lua.LState) int {
+	l1 := checkLuaList(L, 1)
+	l2 := checkLuaList(L, 2)
+
+	res := make([]interface{}, 0, len(l1.l)+len(l2.l))
+	res = append(res, l1.l...)
+	res = append(res, l2.l...)
+	L.Push(newLuaListUserData(L, &luaList{l: res}))
+	return 1
+}
+
+func luaListIterator(L *lua.LState) int {
+	l := checkLuaList(L, 1)
+
+	i := 0
+	L.Push(L.NewFunction(func(L *lua.LState) int {
+		if i >= len(l.l) {
+			L.Push(lua.LNil)
+			return 1
+		}
+		i++
+		L.Push(toLValue(L, l.l[i-1]))
+		return 1
+	}))
+	return 1
+}
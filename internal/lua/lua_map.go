@@ -0,0 +1,237 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lua
+
+import (
+	"fmt"
+
+	lua "github.com/yuin/gopher-lua"
+)
+
+const luaMapTypeName = "LuaMap"
+
+// luaMap is the aerospike map type inside the Lua state.
+type luaMap struct {
+	m map[interface{}]interface{}
+}
+
+var luaMapFunctions = map[string]lua.LGFunction{
+	"create":   luaMapCreate,
+	"size":     luaMapSize,
+	"pairs":    luaMapPairs,
+	"iterator": luaMapPairs,
+	"keys":     luaMapKeys,
+	"values":   luaMapValues,
+	"remove":   luaMapRemove,
+	"clone":    luaMapClone,
+	"merge":    luaMapMerge,
+	"diff":     luaMapDiff,
+}
+
+func registerLuaMapType(L *lua.LState) {
+	mt := L.NewTypeMetatable(luaMapTypeName)
+	L.SetField(mt, "__index", L.NewFunction(luaMapIndex))
+	L.SetField(mt, "__newindex", L.NewFunction(luaMapNewIndex))
+	L.SetField(mt, "__len", L.NewFunction(luaMapSize))
+	L.SetField(mt, "__tostring", L.NewFunction(luaMapToString))
+
+	// the module is callable, so both map() and map{...} construct a map
+	mod := L.SetFuncs(L.NewTable(), luaMapFunctions)
+	modMt := L.NewTable()
+	L.SetField(modMt, "__call", L.NewFunction(luaMapCall))
+	L.SetMetatable(mod, modMt)
+	L.SetGlobal("map", mod)
+}
+
+func newLuaMapUserData(L *lua.LState, m *luaMap) *lua.LUserData {
+	ud := L.NewUserData()
+	ud.Value = m
+	L.SetMetatable(ud, L.GetTypeMetatable(luaMapTypeName))
+	return ud
+}
+
+func checkLuaMap(L *lua.LState, n int) *luaMap {
+	ud := L.CheckUserData(n)
+	if m, ok := ud.Value.(*luaMap); ok {
+		return m
+	}
+	L.ArgError(n, "map expected")
+	return nil
+}
+
+func luaMapFromTable(L *lua.LState, n int) *luaMap {
+	m := &luaMap{m: make(map[interface{}]interface{})}
+	if tbl, ok := L.Get(n).(*lua.LTable); ok {
+		tbl.ForEach(func(k, v lua.LValue) {
+			m.m[fromLValue(k)] = fromLValue(v)
+		})
+	}
+	return m
+}
+
+// map(...) is called on the module table, so the arguments start at 2.
+func luaMapCall(L *lua.LState) int {
+	L.Push(newLuaMapUserData(L, luaMapFromTable(L, 2)))
+	return 1
+}
+
+func luaMapCreate(L *lua.LState) int {
+	L.Push(newLuaMapUserData(L, luaMapFromTable(L, 1)))
+	return 1
+}
+
+func luaMapIndex(L *lua.LState) int {
+	m := checkLuaMap(L, 1)
+	L.Push(toLValue(L, m.m[fromLValue(L.Get(2))]))
+	return 1
+}
+
+func luaMapNewIndex(L *lua.LState) int {
+	m := checkLuaMap(L, 1)
+	key := fromLValue(L.Get(2))
+	if value := L.Get(3); value == lua.LNil {
+		delete(m.m, key)
+	} else {
+		m.m[key] = fromLValue(value)
+	}
+	return 0
+}
+
+func luaMapSize(L *lua.LState) int {
+	m := checkLuaMap(L, 1)
+	L.Push(lua.LNumber(len(m.m)))
+	return 1
+}
+
+func luaMapToString(L *lua.LState) int {
+	m := checkLuaMap(L, 1)
+	L.Push(lua.LString(fmt.Sprintf("%v", export(m))))
+	return 1
+}
+
+// luaMapIterator returns an iterator over a snapshot of the map keys.
+// The push function pushes the results for each key and returns their count.
+func luaMapIterator(L *lua.LState, m *luaMap, push func(L *lua.LState, k, v interface{}) int) int {
+	keys := make([]interface{}, 0, len(m.m))
+	for k := range m.m {
+		keys = append(keys, k)
+	}
+
+	i := 0
+	L.Push(L.NewFunction(func(L *lua.LState) int {
+		for i < len(keys) {
+			k := keys[i]
+			i++
+			// skip keys removed during the iteration
+			if v, exists := m.m[k]; exists {
+				return push(L, k, v)
+			}
+		}
+		L.Push(lua.LNil)
+		return 1
+	}))
+	return 1
+}
+
+func luaMapPairs(L *lua.LState) int {
+	return luaMapIterator(L, checkLuaMap(L, 1), func(L *lua.LState, k, v interface{}) int {
+		L.Push(toLValue(L, k))
+		L.Push(toLValue(L, v))
+		return 2
+	})
+}
+
+func luaMapKeys(L *lua.LState) int {
+	return luaMapIterator(L, checkLuaMap(L, 1), func(L *lua.LState, k, v interface{}) int {
+		L.Push(toLValue(L, k))
+		return 1
+	})
+}
+
+func luaMapValues(L *lua.LState) int {
+	return luaMapIterator(L, checkLuaMap(L, 1), func(L *lua.LState, k, v interface{}) int {
+		L.Push(toLValue(L, v))
+		return 1
+	})
+}
+
+func luaMapRemove(L *lua.LState) int {
+	m := checkLuaMap(L, 1)
+	delete(m.m, fromLValue(L.Get(2)))
+	return 0
+}
+
+func (m *luaMap) clone() *luaMap {
+	res := &luaMap{m: make(map[interface{}]interface{}, len(m.m))}
+	for k, v := range m.m {
+		res.m[k] = v
+	}
+	return res
+}
+
+func luaMapClone(L *lua.LState) int {
+	m := checkLuaMap(L, 1)
+	L.Push(newLuaMapUserData(L, m.clone()))
+	return 1
+}
+
+// merge(m1, m2, f) returns a new map with the entries of both maps.
+// Values of keys present in both maps are merged by calling f(v1, v2).
+func luaMapMerge(L *lua.LState) int {
+	m1 := checkLuaMap(L, 1)
+	m2 := checkLuaMap(L, 2)
+	fn := L.OptFunction(3, nil)
+
+	res := m1.clone()
+	for k, v2 := range m2.m {
+		v1, exists := res.m[k]
+		if !exists || fn == nil {
+			res.m[k] = v2
+			continue
+		}
+
+		L.Push(fn)
+		L.Push(toLValue(L, v1))
+		L.Push(toLValue(L, v2))
+		L.Call(2, 1)
+		res.m[k] = fromLValue(L.Get(-1))
+		L.Pop(1)
+	}
+
+	L.Push(newLuaMapUserData(L, res))
+	return 1
+}
+
+// diff(m1, m2) returns a new map with the entries whose keys are
+// present in only one of the maps.
+func luaMapDiff(L *lua.LState) int {
+	m1 := checkLuaMap(L, 1)
+	m2 := checkLuaMap(L, 2)
+
+	res := &luaMap{m: make(map[interface{}]interface{})}
+	for k, v := range m1.m {
+		if _, exists := m2.m[k]; !exists {
+			res.m[k] = v
+		}
+	}
+	for k, v := range m2.m {
+		if _, exists := m1.m[k]; !exists {
+			res.m[k] = v
+		}
+	}
+
+	L.Push(newLuaMapUserData(L, res))
+	return 1
+}
@@ -0,0 +1,72 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lua
+
+import (
+	lua "github.com/yuin/gopher-lua"
+)
+
+const luaStreamTypeName = "LuaStream"
+
+// luaStream connects a Go channel to the stream functions of the Lua state.
+type luaStream struct {
+	c chan interface{}
+}
+
+var luaStreamFunctions = map[string]lua.LGFunction{
+	"read":  luaStreamRead,
+	"write": luaStreamWrite,
+}
+
+func registerLuaStreamType(L *lua.LState) {
+	L.NewTypeMetatable(luaStreamTypeName)
+	L.SetGlobal("stream", L.SetFuncs(L.NewTable(), luaStreamFunctions))
+}
+
+// NewLuaStream wraps the channel in a stream object which can be passed
+// to the Lua state. Reading from the stream returns nil once the
+// channel is closed.
+func NewLuaStream(L *lua.LState, c chan interface{}) *lua.LUserData {
+	ud := L.NewUserData()
+	ud.Value = &luaStream{c: c}
+	L.SetMetatable(ud, L.GetTypeMetatable(luaStreamTypeName))
+	return ud
+}
+
+func checkLuaStream(L *lua.LState, n int) *luaStream {
+	ud := L.CheckUserData(n)
+	if s, ok := ud.Value.(*luaStream); ok {
+		return s
+	}
+	L.ArgError(n, "stream expected")
+	return nil
+}
+
+func luaStreamRead(L *lua.LState) int {
+	s := checkLuaStream(L, 1)
+	v, ok := <-s.c
+	if !ok {
+		L.Push(lua.LNil)
+		return 1
+	}
+	L.Push(NewValue(L, v))
+	return 1
+}
+
+func luaStreamWrite(L *lua.LState) int {
+	s := checkLuaStream(L, 1)
+	s.c <- LValueToInterface(L.Get(2))
+	return 0
+}
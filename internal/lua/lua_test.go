@@ -0,0 +1,187 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lua_test
+
+import (
+	ilua "github.com/aerospike/aerospike-client-go/internal/lua"
+	lua "github.com/yuin/gopher-lua"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const streamUDF = `
+local function add(a, b)
+    return a + b
+end
+
+function sum(s)
+    return s : reduce(add)
+end
+
+function sum_of_evens(s)
+    local function even(v)
+        return v % 2 == 0
+    end
+    return s : filter(even) : reduce(add)
+end
+
+function count_by_parity(s)
+    local function parity(m, v)
+        local k = "odd"
+        if v % 2 == 0 then
+            k = "even"
+        end
+        m[k] = (m[k] or 0) + 1
+        return m
+    end
+    local function merge(m1, m2)
+        return map.merge(m1, m2, add)
+    end
+    return s : aggregate(map(), parity) : reduce(merge)
+end
+
+function collect(s)
+    local function wrap(v)
+        return list{v}
+    end
+    local function concat(l1, l2)
+        return list.merge(l1, l2)
+    end
+    return s : map(wrap) : reduce(concat)
+end
+`
+
+// applyStream runs the given stage of the stream UDF on the input values
+// and returns the values written to the output stream.
+func applyStream(fn string, scope int, input ...interface{}) ([]interface{}, error) {
+	L, err := ilua.NewInstance()
+	if err != nil {
+		return nil, err
+	}
+	defer L.Close()
+
+	if err := L.DoString(streamUDF); err != nil {
+		return nil, err
+	}
+
+	istream := make(chan interface{}, len(input))
+	for _, v := range input {
+		istream <- v
+	}
+	close(istream)
+
+	ostream := make(chan interface{}, 16)
+	err = L.CallByParam(lua.P{
+		Fn:      L.GetGlobal("apply_stream"),
+		NRet:    1,
+		Protect: true,
+	},
+		L.GetGlobal(fn),
+		lua.LNumber(scope),
+		ilua.NewLuaStream(L, istream),
+		ilua.NewLuaStream(L, ostream),
+	)
+	close(ostream)
+
+	var res []interface{}
+	for v := range ostream {
+		res = append(res, v)
+	}
+	return res, err
+}
+
+var _ = Describe("Lua", func() {
+
+	Context("Stream operations", func() {
+
+		It("must reduce the stream on the client", func() {
+			res, err := applyStream("sum", 2, 1, 2, 3, 4)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(Equal([]interface{}{10}))
+		})
+
+		It("must only run the operations after the first reduce on the client", func() {
+			// the filter has already been applied on the server
+			res, err := applyStream("sum_of_evens", 2, 1, 2, 3, 4)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(Equal([]interface{}{10}))
+
+			res, err = applyStream("sum_of_evens", 1, 1, 2, 3, 4)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(Equal([]interface{}{6}))
+		})
+
+		It("must run aggregations on the server", func() {
+			res, err := applyStream("count_by_parity", 1, 1, 2, 3, 4, 5)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(Equal([]interface{}{map[interface{}]interface{}{"odd": 3, "even": 2}}))
+		})
+
+		It("must merge maps from the server on the client", func() {
+			res, err := applyStream("count_by_parity", 2,
+				map[interface{}]interface{}{"odd": 3, "even": 2},
+				map[interface{}]interface{}{"odd": 1},
+			)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(Equal([]interface{}{map[interface{}]interface{}{"odd": 4, "even": 2}}))
+		})
+
+		It("must merge lists", func() {
+			res, err := applyStream("collect", 1, "a", "b", 1.5)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(Equal([]interface{}{[]interface{}{"a", "b", 1.5}}))
+		})
+
+		It("must return nothing for an empty stream", func() {
+			res, err := applyStream("sum", 2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(BeEmpty())
+		})
+
+		It("must return the errors raised by the UDF", func() {
+			_, err := applyStream("sum", 2, "a", 1)
+			Expect(err).To(HaveOccurred())
+		})
+
+	})
+
+	Context("Value conversion", func() {
+
+		It("must convert values to Lua and back", func() {
+			L, err := ilua.NewInstance()
+			Expect(err).ToNot(HaveOccurred())
+			defer L.Close()
+
+			Expect(ilua.LValueToInterface(ilua.NewValue(L, nil))).To(BeNil())
+
+			values := []interface{}{
+				"string",
+				1,
+				-1 << 40,
+				1.5,
+				true,
+				[]interface{}{1, "a", []interface{}{2}},
+				map[interface{}]interface{}{"a": 1, 2: []interface{}{"b"}},
+			}
+
+			for _, v := range values {
+				Expect(ilua.LValueToInterface(ilua.NewValue(L, v))).To(Equal(v))
+			}
+		})
+
+	})
+
+})
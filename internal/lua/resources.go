@@ -0,0 +1,163 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lua
+
+// luaStreamOps defines the stream operations available to stream UDFs,
+// and the scope in which each of them runs.
+const luaStreamOps = `
+SCOPE_SERVER = 1
+SCOPE_CLIENT = 2
+SCOPE_EITHER = 3
+SCOPE_BOTH   = 4
+
+local StreamOps = {}
+StreamOps.__index = StreamOps
+
+function StreamOps_create()
+    return setmetatable({ ops = {} }, StreamOps)
+end
+
+local function add_op(self, scope, name, ...)
+    table.insert(self.ops, { scope = scope, name = name, args = {...} })
+    return self
+end
+
+function StreamOps:aggregate(init, f)
+    return add_op(self, SCOPE_SERVER, "aggregate", init, f)
+end
+
+function StreamOps:filter(f)
+    return add_op(self, SCOPE_EITHER, "filter", f)
+end
+
+function StreamOps:map(f)
+    return add_op(self, SCOPE_EITHER, "map", f)
+end
+
+function StreamOps:reduce(f)
+    return add_op(self, SCOPE_BOTH, "reduce", f)
+end
+
+-- The server runs the operations up to and including the first operation
+-- with SCOPE_BOTH; the client runs the rest, starting from that operation.
+function StreamOps_select(ops, scope)
+    local sops = {}
+    local both = false
+    for _, op in ipairs(ops) do
+        if scope == SCOPE_SERVER then
+            table.insert(sops, op)
+            if op.scope == SCOPE_BOTH then
+                break
+            end
+        else
+            if op.scope == SCOPE_BOTH then
+                both = true
+            end
+            if both then
+                table.insert(sops, op)
+            end
+        end
+    end
+    return sops
+end
+
+local function op_filter(next, f)
+    return function()
+        local v = next()
+        while v ~= nil and not f(v) do
+            v = next()
+        end
+        return v
+    end
+end
+
+local function op_map(next, f)
+    return function()
+        local v = next()
+        if v ~= nil then
+            return f(v)
+        end
+        return nil
+    end
+end
+
+local function op_aggregate(next, init, f)
+    local done = false
+    return function()
+        if done then
+            return nil
+        end
+        done = true
+        local acc = init
+        local v = next()
+        while v ~= nil do
+            acc = f(acc, v)
+            v = next()
+        end
+        return acc
+    end
+end
+
+local function op_reduce(next, f)
+    local done = false
+    return function()
+        if done then
+            return nil
+        end
+        done = true
+        local acc = next()
+        if acc == nil then
+            return nil
+        end
+        local v = next()
+        while v ~= nil do
+            acc = f(acc, v)
+            v = next()
+        end
+        return acc
+    end
+end
+
+local op_funcs = {
+    aggregate = op_aggregate,
+    filter    = op_filter,
+    map       = op_map,
+    reduce    = op_reduce,
+}
+
+-- Chains the operations on top of the iterator and returns the last one.
+function StreamOps_apply(next, ops)
+    for _, op in ipairs(ops) do
+        next = op_funcs[op.name](next, op.args[1], op.args[2])
+    end
+    return next
+end
+`
+
+// luaAerospike defines the entry point used by the client to run the
+// client-side stage of a stream UDF.
+const luaAerospike = `
+function apply_stream(f, scope, istream, ostream, ...)
+    local ops = StreamOps_select(f(StreamOps_create(), ...).ops, scope)
+    local next = StreamOps_apply(function() return stream.read(istream) end, ops)
+
+    local v = next()
+    while v ~= nil do
+        stream.write(ostream, v)
+        v = next()
+    end
+    return 0
+end
+`
@@ -0,0 +1,217 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/aerospike/aerospike-client-go/types"
+)
+
+// JobType determines the type of the server jobs.
+type JobType string
+
+const (
+	// JOB_SCAN is the type of scan jobs, including scan UDFs and background scans.
+	JOB_SCAN JobType = "scan"
+	// JOB_QUERY is the type of query jobs, including query UDFs and background queries.
+	JOB_QUERY JobType = "query"
+)
+
+// JobStatus determines the status of a server job.
+type JobStatus string
+
+const (
+	// JOB_STATUS_IN_PROGRESS means the job is still running.
+	JOB_STATUS_IN_PROGRESS JobStatus = "IN PROGRESS"
+	// JOB_STATUS_DONE means the job has finished successfully.
+	JOB_STATUS_DONE JobStatus = "DONE"
+	// JOB_STATUS_ABORTED means the job was aborted by the user or the server.
+	JOB_STATUS_ABORTED JobStatus = "ABORTED"
+	// JOB_STATUS_UNKNOWN means the status reported by the server was not recognized.
+	JOB_STATUS_UNKNOWN JobStatus = "UNKNOWN"
+)
+
+// JobInfo describes a scan or query job on a server node.
+// The server keeps the finished jobs in its job list for a while,
+// so the list also contains the jobs which are done or aborted.
+type JobInfo struct {
+	// Node is the node which reported the job.
+	Node *Node
+
+	// Type is the type of the job.
+	Type JobType
+
+	// TaskId is the id of the job. It is the TaskId of the statement
+	// or the recordset which started the job.
+	TaskId int64
+
+	// Status is the status of the job on the node.
+	Status JobStatus
+
+	// Progress is the percentage of the job completed on the node.
+	Progress int
+
+	// Namespace and SetName determine the records of the job, if reported by the server.
+	Namespace string
+	SetName   string
+
+	// Attributes contain all the attributes of the job, as reported by the server.
+	Attributes map[string]string
+}
+
+// the job attributes have been renamed between server versions
+var (
+	jobIdAttrs       = []string{"trid", "job_id"}
+	jobStatusAttrs   = []string{"status", "job-status", "job_status"}
+	jobProgressAttrs = []string{"job-progress", "job_progress(%)"}
+	jobNsAttrs       = []string{"ns", "namespace"}
+	jobSetAttrs      = []string{"set", "set_name"}
+)
+
+func jobAttr(attrs map[string]string, names []string) string {
+	for _, name := range names {
+		if v, exists := attrs[name]; exists {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseJobStatus normalizes the statuses of the different server versions,
+// e.g. "IN PROGRESS", "active(ok)", "DONE", "done(ok)" or "done(user-aborted)".
+func parseJobStatus(status string) JobStatus {
+	status = strings.ToLower(strings.TrimSpace(status))
+
+	switch {
+	case strings.HasPrefix(status, "in progress"), strings.HasPrefix(status, "in-progress"), strings.HasPrefix(status, "active"):
+		return JOB_STATUS_IN_PROGRESS
+	case status == "done", status == "done(ok)", status == "done(response-complete)":
+		return JOB_STATUS_DONE
+	case strings.HasPrefix(status, "aborted"), strings.HasPrefix(status, "done("):
+		return JOB_STATUS_ABORTED
+	}
+	return JOB_STATUS_UNKNOWN
+}
+
+// parseJobs parses the job list returned by the server. The jobs are
+// separated by ';' and each job is a list of 'name=value' attributes
+// separated by ':'.
+func parseJobs(node *Node, jobType JobType, response string) []*JobInfo {
+	var res []*JobInfo
+
+	for _, job := range strings.Split(response, ";") {
+		if strings.TrimSpace(job) == "" {
+			continue
+		}
+
+		attrs := make(map[string]string)
+		for _, attr := range strings.Split(job, ":") {
+			if kv := strings.SplitN(attr, "=", 2); len(kv) == 2 {
+				attrs[kv[0]] = kv[1]
+			}
+		}
+
+		// the ids are unsigned on the server
+		id, err := strconv.ParseUint(jobAttr(attrs, jobIdAttrs), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		progress, _ := strconv.ParseFloat(jobAttr(attrs, jobProgressAttrs), 64)
+
+		res = append(res, &JobInfo{
+			Node:       node,
+			Type:       jobType,
+			TaskId:     int64(id),
+			Status:     parseJobStatus(jobAttr(attrs, jobStatusAttrs)),
+			Progress:   int(progress),
+			Namespace:  jobAttr(attrs, jobNsAttrs),
+			SetName:    jobAttr(attrs, jobSetAttrs),
+			Attributes: attrs,
+		})
+	}
+
+	return res
+}
+
+// requestJobInfo sends the info command to the node, falling back to the
+// legacy command if the server does not support the jobs command.
+func requestJobInfo(node *Node, timeout time.Duration, command, legacyCommand string) (string, error) {
+	responseMap, err := requestNodeInfo(node, timeout, command)
+	if err != nil {
+		return "", err
+	}
+
+	if response, exists := responseMap[command]; exists && !strings.HasPrefix(strings.ToLower(response), "error") {
+		return response, nil
+	}
+
+	responseMap, err = requestNodeInfo(node, timeout, legacyCommand)
+	if err != nil {
+		return "", err
+	}
+	return responseMap[legacyCommand], nil
+}
+
+// requestNodeJobs returns the jobs of the type on the node.
+func requestNodeJobs(node *Node, timeout time.Duration, jobType JobType) ([]*JobInfo, error) {
+	response, err := requestJobInfo(node, timeout, "jobs:module="+string(jobType), string(jobType)+"-list")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseJobs(node, jobType, response), nil
+}
+
+// requestNodeJob returns the job with the id on the node,
+// or nil if the node does not know about the job.
+func requestNodeJob(node *Node, timeout time.Duration, jobType JobType, taskId int64) (*JobInfo, error) {
+	jobs, err := requestNodeJobs(node, timeout, jobType)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, job := range jobs {
+		if job.TaskId == taskId {
+			return job, nil
+		}
+	}
+	return nil, nil
+}
+
+// abortNodeJob aborts the job with the id on the node.
+func abortNodeJob(node *Node, timeout time.Duration, jobType JobType, taskId int64) error {
+	id := strconv.FormatUint(uint64(taskId), 10)
+
+	var legacyCommand string
+	if jobType == JOB_SCAN {
+		legacyCommand = "scan-abort:id=" + id
+	} else {
+		legacyCommand = "query-kill:trid=" + id
+	}
+
+	response, err := requestJobInfo(node, timeout, "jobs:module="+string(jobType)+";cmd=kill-job;trid="+id, legacyCommand)
+	if err != nil {
+		return err
+	}
+
+	if strings.ToUpper(strings.TrimSpace(response)) != "OK" {
+		return NewAerospikeError(SERVER_ERROR, "Failed to abort job "+id+" on node "+node.GetName()+": "+response)
+	}
+	return nil
+}
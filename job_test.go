@@ -0,0 +1,74 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Job Info Test", func() {
+
+	It("should parse the jobs command response", func() {
+		response := "module=scan:trid=12345:job-type=basic:ns=test:set=demo:priority=0:status=active(ok):job-progress=42.57:run-time=100;" +
+			"module=scan:trid=18446744073709551615:ns=test:set=:status=done(user-aborted):job-progress=10.00;" +
+			"module=scan:trid=7:ns=bar:status=done(ok):job-progress=100.00"
+
+		jobs := parseJobs(nil, JOB_SCAN, response)
+		Expect(len(jobs)).To(Equal(3))
+
+		Expect(jobs[0].Type).To(Equal(JOB_SCAN))
+		Expect(jobs[0].TaskId).To(Equal(int64(12345)))
+		Expect(jobs[0].Status).To(Equal(JOB_STATUS_IN_PROGRESS))
+		Expect(jobs[0].Progress).To(Equal(42))
+		Expect(jobs[0].Namespace).To(Equal("test"))
+		Expect(jobs[0].SetName).To(Equal("demo"))
+		Expect(jobs[0].Attributes["job-type"]).To(Equal("basic"))
+
+		// ids are unsigned on the server
+		Expect(jobs[1].TaskId).To(Equal(int64(-1)))
+		Expect(jobs[1].Status).To(Equal(JOB_STATUS_ABORTED))
+		Expect(jobs[1].SetName).To(Equal(""))
+
+		Expect(jobs[2].TaskId).To(Equal(int64(7)))
+		Expect(jobs[2].Status).To(Equal(JOB_STATUS_DONE))
+		Expect(jobs[2].Progress).To(Equal(100))
+	})
+
+	It("should parse the legacy scan-list and query-list responses", func() {
+		response := "job_id=5:job_status=IN PROGRESS:job_progress(%)=30:namespace=test:set_name=demo;" +
+			"job_id=6:job_status=DONE:job_progress(%)=100;" +
+			"job_id=8:job_status=ABORTED:job_progress(%)=12;"
+
+		jobs := parseJobs(nil, JOB_QUERY, response)
+		Expect(len(jobs)).To(Equal(3))
+
+		Expect(jobs[0].Type).To(Equal(JOB_QUERY))
+		Expect(jobs[0].TaskId).To(Equal(int64(5)))
+		Expect(jobs[0].Status).To(Equal(JOB_STATUS_IN_PROGRESS))
+		Expect(jobs[0].Progress).To(Equal(30))
+		Expect(jobs[0].Namespace).To(Equal("test"))
+		Expect(jobs[0].SetName).To(Equal("demo"))
+
+		Expect(jobs[1].Status).To(Equal(JOB_STATUS_DONE))
+		Expect(jobs[2].Status).To(Equal(JOB_STATUS_ABORTED))
+	})
+
+	It("should skip empty and invalid jobs", func() {
+		Expect(parseJobs(nil, JOB_SCAN, "")).To(BeEmpty())
+		Expect(parseJobs(nil, JOB_SCAN, "module=scan:status=done(ok);;")).To(BeEmpty())
+	})
+
+})
@@ -97,6 +97,17 @@ func NewKey(namespace string, setName string, key interface{}) (newKey *Key, err
 	return newKey, err
 }
 
+// NewObjectKey initializes a key from namespace, optional set name and the value of
+// the object's field tagged with the key option, e.g. `as:"id,key"`.
+// Returns a PARAMETER_ERROR if obj is not a struct or has no key field.
+func NewObjectKey(namespace string, setName string, obj interface{}) (*Key, error) {
+	key, err := objectKeyValue(obj)
+	if err != nil {
+		return nil, err
+	}
+	return NewKey(namespace, setName, key)
+}
+
 // NewKey initializes a key from namespace, optional set name and user key.
 // The server handles record identifiers by digest only.
 func NewKeyWithDigest(namespace string, setName string, key interface{}, digest []byte) (newKey *Key, err error) {
@@ -124,6 +135,10 @@ func (ky *Key) SetDigest(digest []byte) error {
 // Generate unique server hash value from set name, key type and user defined key.
 // The hash function is RIPEMD-160 (a 160 bit hash).
 func computeDigest(key *Key) ([]byte, error) {
+	if vl, ok := key.userKey.(*errorValue); ok {
+		return nil, vl.err
+	}
+
 	keyType := key.userKey.GetType()
 
 	if keyType == ParticleType.NULL {
@@ -142,16 +157,19 @@ func computeDigest(key *Key) ([]byte, error) {
 	buf.Reset()
 	buf.WriteString(key.setName)
 	buf.WriteByte(byte(keyType))
-	buf.ReadFrom(key.userKey.reader())
+	_, err := buf.ReadFrom(key.userKey.reader())
 
-	h.Write(buf.Bytes())
-	res := h.Sum(nil)
+	var res []byte
+	if err == nil {
+		h.Write(buf.Bytes())
+		res = h.Sum(nil)
+	}
 
 	// put hash object back to the pool
 	hashPool.Put(h)
 	keyBufPool.Put(buf)
 
-	return res, nil
+	return res, err
 }
 
 // hash pool
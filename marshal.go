@@ -15,203 +15,858 @@
 package aerospike
 
 import (
+	"encoding/json"
+	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"strings"
 	"sync"
 	"time"
+
+	. "github.com/aerospike/aerospike-client-go/types"
 )
 
 const (
 	aerospikeTag = "as"
+	metadataTag  = "asm"
 	keyTag       = "key"
 )
 
-func valueToInterface(f reflect.Value) interface{} {
+// BinMarshaler is implemented by objects which write themselves to the record
+// bins in PutObject, instead of the bins of their exported fields.
+type BinMarshaler interface {
+	MarshalBins() (BinMap, error)
+}
+
+// BinUnmarshaler is implemented by objects which read themselves from the
+// record bins in GetObject, instead of setting their exported fields.
+// All the bins of the record are read.
+type BinUnmarshaler interface {
+	UnmarshalBins(bins BinMap) error
+}
+
+// ObjectMarshaler is implemented by the code the asgen tool generates for a struct,
+// and is used by PutObject in place of reflection.
+type ObjectMarshaler interface {
+	ToBins() ([]*Bin, error)
+}
+
+// ObjectUnmarshaler is implemented by the code the asgen tool generates for a struct,
+// and is used by GetObject in place of reflection. All the bins of the record are read.
+type ObjectUnmarshaler interface {
+	FromBins(bins BinMap) error
+}
+
+// ObjectMetadata is implemented by the code the asgen tool generates for structs with
+// fields tagged with `asm:"gen"` or `asm:"ttl"`, so that PutObject and GetObject can
+// use them without reflection. Generated marshalers which do not implement it have no
+// metadata fields.
+type ObjectMetadata interface {
+	// MetadataWritePolicy returns a copy of the policy with the generation and the
+	// expiration of the metadata fields. A zero expiration keeps the policy's expiration.
+	MetadataWritePolicy(policy *WritePolicy) *WritePolicy
+	// SetMetadata sets the record's generation and expiration to the metadata fields.
+	SetMetadata(generation, expiration int)
+}
+
+var (
+	binMarshalerType     = reflect.TypeOf((*BinMarshaler)(nil)).Elem()
+	binUnmarshalerType   = reflect.TypeOf((*BinUnmarshaler)(nil)).Elem()
+	valueMarshalerType   = reflect.TypeOf((*ValueMarshaler)(nil)).Elem()
+	valueUnmarshalerType = reflect.TypeOf((*ValueUnmarshaler)(nil)).Elem()
+	customValueType      = reflect.TypeOf((*CustomValue)(nil)).Elem()
+	mapPairsType         = reflect.TypeOf([]MapPair(nil))
+)
+
+// implementation returns v, or the first value found by following its pointers,
+// which implements the interface type t.
+func implementation(v interface{}, t reflect.Type) (interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() {
+		if rv.Type().Implements(t) {
+			return rv.Interface(), true
+		}
+		if rv.Kind() != reflect.Ptr || rv.IsNil() {
+			break
+		}
+		rv = rv.Elem()
+	}
+	return nil, false
+}
+
+// marshalValue returns f if it is a CustomValue, or calls the ValueMarshaler
+// implementation of f, either on its value or on its address.
+func marshalValue(f reflect.Value) (interface{}, bool, error) {
+	if f.Type().Implements(customValueType) {
+		if (f.Kind() == reflect.Ptr || f.Kind() == reflect.Interface) && f.IsNil() {
+			return nil, true, nil
+		}
+		return f.Interface(), true, nil
+	}
+
+	if f.CanAddr() && f.Addr().Type().Implements(customValueType) {
+		return f.Addr().Interface(), true, nil
+	}
+
+	if f.Type().Implements(valueMarshalerType) {
+		if (f.Kind() == reflect.Ptr || f.Kind() == reflect.Interface) && f.IsNil() {
+			return nil, true, nil
+		}
+		v, err := f.Interface().(ValueMarshaler).MarshalValue()
+		return v, true, err
+	}
+
+	if f.CanAddr() && f.Addr().Type().Implements(valueMarshalerType) {
+		v, err := f.Addr().Interface().(ValueMarshaler).MarshalValue()
+		return v, true, err
+	}
+	return nil, false, nil
+}
+
+// valueToInterface returns the value to persist for f. Booleans are returned as
+// integers 1 and 0, unless useBoolBin is set.
+func valueToInterface(f reflect.Value, useBoolBin bool) (interface{}, error) {
+	if v, ok, err := marshalValue(f); ok {
+		return v, err
+	}
+
 	// get to the core value
 	for f.Kind() == reflect.Ptr {
 		if f.IsNil() {
-			return nil
+			return nil, nil
 		}
 		f = reflect.Indirect(f)
+
+		if v, ok, err := marshalValue(f); ok {
+			return v, err
+		}
 	}
 
 	switch f.Kind() {
 	case reflect.Uint64:
-		return int64(f.Uint())
+		return f.Uint(), nil
 	case reflect.Float64, reflect.Float32:
-		return int(math.Float64bits(f.Float()))
+		return f.Float(), nil
 	case reflect.Struct:
 		if f.Type().PkgPath() == "time" && f.Type().Name() == "Time" {
-			return f.Interface().(time.Time).UTC().UnixNano()
+			// zero time is out of the range of UnixNano
+			if f.Interface().(time.Time).IsZero() {
+				return nil, nil
+			}
+			return f.Interface().(time.Time).UTC().UnixNano(), nil
+		} else if f.Type().PkgPath() == "math/big" && f.Type().Name() == "Int" {
+			v := f.Interface().(big.Int)
+			return &v, nil
 		} else {
-			return structToMap(f)
+			m, err := structToMap(f, useBoolBin)
+			if m == nil {
+				// do not return a typed nil
+				return nil, err
+			}
+			return m, err
 		}
 	case reflect.Bool:
-		if f.Bool() == true {
-			return int64(1)
+		if useBoolBin {
+			return f.Bool(), nil
 		}
-		return int64(0)
-	case reflect.Map, reflect.Slice, reflect.Interface:
+		if f.Bool() {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case reflect.Interface:
 		if f.IsNil() {
-			return nil
+			return nil, nil
+		}
+		return valueToInterface(f.Elem(), useBoolBin)
+	case reflect.Map:
+		if f.IsNil() {
+			return nil, nil
 		}
-		return f.Interface()
+
+		m := make(map[interface{}]interface{}, f.Len())
+		for _, k := range f.MapKeys() {
+			key, err := valueToInterface(k, useBoolBin)
+			if err != nil {
+				return nil, err
+			}
+
+			elem, err := valueToInterface(f.MapIndex(k), useBoolBin)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = elem
+		}
+		return m, nil
+	case reflect.Slice:
+		if f.IsNil() {
+			return nil, nil
+		}
+
+		// BLOBs
+		if f.Type().Elem().Kind() == reflect.Uint8 {
+			return f.Bytes(), nil
+		}
+
+		// ordered maps
+		if f.Type() == mapPairsType {
+			return f.Interface(), nil
+		}
+		fallthrough
+	case reflect.Array:
+		l := f.Len()
+		arr := make([]interface{}, l)
+		for i := 0; i < l; i++ {
+			elem, err := valueToInterface(f.Index(i), useBoolBin)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = elem
+		}
+		return arr, nil
 	default:
-		return f.Interface()
+		return f.Interface(), nil
 	}
 }
 
-func fieldAlias(f reflect.StructField) string {
-	alias := f.Tag.Get(aerospikeTag)
-	if alias != "" {
-		alias = strings.Trim(alias, " ")
+// objectField describes how a struct field is mapped to a bin.
+type objectField struct {
+	index int
+	alias string
+
+	// omit the field if it has its zero value
+	omitEmpty bool
+	// store the field as a JSON blob
+	json bool
+	// store the field as a MessagePack blob
+	msgpack bool
+}
 
-		// if tag is -, the field should not be persisted
-		if alias == "-" {
-			return ""
-		}
-		return alias
-	} else {
-		return f.Name
-	}
+// objectInfo describes how a struct type is mapped to a record.
+type objectInfo struct {
+	fields   []*objectField
+	byAlias  map[string]*objectField
+	binNames []string
+
+	// indexes of the fields tagged as the key, and with the record's metadata; -1 if none
+	keyField int
+	genField int
+	ttlField int
 }
 
-func structToMap(s reflect.Value) map[string]interface{} {
-	if !s.IsValid() {
-		return nil
+// parseFieldTag parses the field's `as` tag, which consists of the bin name,
+// followed by the comma separated options. The bin name defaults to the field
+// name, and is empty for fields which are not persisted.
+func parseFieldTag(f reflect.StructField) (string, []string) {
+	parts := strings.Split(f.Tag.Get(aerospikeTag), ",")
+	alias := strings.Trim(parts[0], " ")
+
+	// if tag is -, the field should not be persisted
+	if alias == "-" {
+		return "", nil
 	}
 
-	// map tags
-	cacheObjectTags(s)
+	if alias == "" {
+		alias = f.Name
+	}
 
-	typeOfT := s.Type()
-	numFields := s.NumField()
+	options := parts[1:]
+	for i := range options {
+		options[i] = strings.Trim(options[i], " ")
+	}
+	return alias, options
+}
 
-	var binMap map[string]interface{}
-	for i := 0; i < numFields; i++ {
+// objectInfoCache caches the mapping of the struct types.
+var objectInfoCache = struct {
+	sync.RWMutex
+	infos map[reflect.Type]*objectInfo
+}{infos: map[reflect.Type]*objectInfo{}}
+
+func getObjectInfo(t reflect.Type) *objectInfo {
+	objectInfoCache.RLock()
+	info, exists := objectInfoCache.infos[t]
+	objectInfoCache.RUnlock()
+	if exists {
+		return info
+	}
+
+	info = &objectInfo{
+		byAlias:  make(map[string]*objectField, t.NumField()),
+		keyField: -1,
+		genField: -1,
+		ttlField: -1,
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
 		// skip unexported fields
-		if typeOfT.Field(i).PkgPath != "" {
+		if sf.PkgPath != "" {
 			continue
 		}
 
-		binValue := valueToInterface(s.Field(i))
+		// metadata fields are not persisted
+		switch strings.Trim(sf.Tag.Get(metadataTag), " ") {
+		case "gen":
+			info.genField = i
+			continue
+		case "ttl":
+			info.ttlField = i
+			continue
+		}
 
-		if binValue != nil {
-			if binMap == nil {
-				binMap = make(map[string]interface{}, numFields)
-			}
+		alias, options := parseFieldTag(sf)
+		if alias == "" {
+			continue
+		}
 
-			alias := fieldAlias(typeOfT.Field(i))
-			if alias == "" {
-				continue
+		fld := &objectField{index: i, alias: alias}
+		for _, option := range options {
+			switch option {
+			case "omitempty":
+				fld.omitEmpty = true
+			case "json":
+				fld.json = true
+			case "msgpack":
+				fld.msgpack = true
+			case keyTag:
+				info.keyField = i
 			}
-
-			binMap[alias] = binValue
 		}
+
+		info.fields = append(info.fields, fld)
+		info.byAlias[alias] = fld
+		info.binNames = append(info.binNames, alias)
 	}
 
-	return binMap
+	objectInfoCache.Lock()
+	objectInfoCache.infos[t] = info
+	objectInfoCache.Unlock()
+
+	return info
 }
 
-func marshal(v interface{}) []*Bin {
-	s := reflect.Indirect(reflect.ValueOf(v).Elem())
-	typeOfT := s.Type()
+// isEmptyValue returns true for the zero values of the types omitted by omitempty.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return v.IsNil()
+	}
+	return false
+}
 
-	// map tags
-	cacheObjectTags(s)
+// fieldToInterface returns the value of the struct field to persist, or nil if
+// the field should not be persisted.
+func fieldToInterface(s reflect.Value, fld *objectField, useBoolBin bool) (interface{}, error) {
+	f := s.Field(fld.index)
+	if fld.omitEmpty && isEmptyValue(f) {
+		return nil, nil
+	}
 
-	numFields := s.NumField()
-	bins := binPool.Get(numFields).([]*Bin)
+	switch {
+	case fld.json:
+		return json.Marshal(f.Interface())
 
-	binCount := 0
-	for i := 0; i < numFields; i++ {
-		// skip unexported fields
-		if typeOfT.Field(i).PkgPath != "" {
-			continue
+	case fld.msgpack:
+		v, err := valueToInterface(f, useBoolBin)
+		if v == nil || err != nil {
+			return nil, err
 		}
 
-		binValue := valueToInterface(s.Field(i))
+		packer := newPacker()
+		if err := packer.PackObject(v); err != nil {
+			return nil, err
+		}
+		return packer.buffer.Bytes(), nil
+	}
+
+	return valueToInterface(f, useBoolBin)
+}
+
+func structToMap(s reflect.Value, useBoolBin bool) (map[string]interface{}, error) {
+	if !s.IsValid() {
+		return nil, nil
+	}
+
+	info := getObjectInfo(s.Type())
+
+	var binMap map[string]interface{}
+	for _, fld := range info.fields {
+		binValue, err := fieldToInterface(s, fld, useBoolBin)
+		if err != nil {
+			return nil, err
+		}
 
 		if binValue != nil {
-			alias := fieldAlias(typeOfT.Field(i))
-			if alias == "" {
-				continue
+			if binMap == nil {
+				binMap = make(map[string]interface{}, len(info.fields))
 			}
 
-			bins[binCount].Name = alias
+			binMap[fld.alias] = binValue
+		}
+	}
+
+	return binMap, nil
+}
+
+// marshal returns the bins of the object. Booleans are stored as integers
+// 1 and 0, which all servers and clients support, unless useBoolBin is set.
+func marshal(v interface{}, useBoolBin bool) ([]*Bin, error) {
+	if bm, ok := implementation(v, binMarshalerType); ok {
+		binMap, err := bm.(BinMarshaler).MarshalBins()
+		if err != nil {
+			return nil, err
+		}
+
+		bins := binPool.Get(len(binMap)).([]*Bin)
+		return binMapToBins(bins[:len(binMap)], binMap), nil
+	}
+
+	s := reflect.Indirect(reflect.ValueOf(v).Elem())
+
+	info := getObjectInfo(s.Type())
+	bins := binPool.Get(len(info.fields)).([]*Bin)
+
+	binCount := 0
+	for _, fld := range info.fields {
+		binValue, err := fieldToInterface(s, fld, useBoolBin)
+		if err != nil {
+			binPool.Put(bins)
+			return nil, err
+		}
+
+		if binValue != nil {
+			bins[binCount].Name = fld.alias
 			bins[binCount].Value = NewValue(binValue)
 			binCount++
 		}
 	}
 
-	return bins[:binCount]
+	return bins[:binCount], nil
 }
 
-type SyncMap struct {
-	objectMappings map[string]map[string]string
-	objectFields   map[string][]string
-	mutex          sync.RWMutex
+// boolBinsToIntegers replaces the boolean bins written by an ObjectMarshaler
+// with integers 1 and 0, the way marshal stores them unless useBoolBin is set.
+func boolBinsToIntegers(bins []*Bin) {
+	for _, bin := range bins {
+		if v, ok := bin.Value.(*BoolValue); ok {
+			if v.value {
+				bin.Value = NewLongValue(1)
+			} else {
+				bin.Value = NewLongValue(0)
+			}
+		}
+	}
 }
 
-func (sm *SyncMap) setMapping(objType string, mapping map[string]string, fields []string) {
-	sm.mutex.Lock()
-	sm.objectMappings[objType] = mapping
-	sm.mutex.Unlock()
+// objectStruct returns the struct which obj points to, if any.
+func objectStruct(obj interface{}) (reflect.Value, bool) {
+	s := reflect.ValueOf(obj)
+	for s.Kind() == reflect.Ptr && !s.IsNil() {
+		s = s.Elem()
+	}
+	return s, s.Kind() == reflect.Struct
 }
 
-func (sm *SyncMap) mappingExists(objType string) bool {
-	sm.mutex.RLock()
-	_, exists := sm.objectMappings[objType]
-	sm.mutex.RUnlock()
-	return exists
+// objectBinNames returns the names of the bins the object's fields are mapped to.
+func objectBinNames(obj interface{}) []string {
+	s, ok := objectStruct(obj)
+	if !ok {
+		return nil
+	}
+	return getObjectInfo(s.Type()).binNames
 }
 
-func (sm *SyncMap) getMapping(objType string) map[string]string {
-	sm.mutex.RLock()
-	mapping := sm.objectMappings[objType]
-	sm.mutex.RUnlock()
-	return mapping
+// objectKeyValue returns the value of the object's key field.
+func objectKeyValue(obj interface{}) (interface{}, error) {
+	s, ok := objectStruct(obj)
+	if !ok {
+		return nil, NewAerospikeError(PARAMETER_ERROR, "Object is not a struct")
+	}
+
+	info := getObjectInfo(s.Type())
+	if info.keyField < 0 {
+		return nil, NewAerospikeError(PARAMETER_ERROR, "Object has no field tagged as the key")
+	}
+	return valueToInterface(s.Field(info.keyField), false)
 }
 
-func (sm *SyncMap) getFields(objType string) []string {
-	sm.mutex.RLock()
-	fields := sm.objectFields[objType]
-	sm.mutex.RUnlock()
-	return fields
+// objectKey returns the key to write the object with. If the object has a field
+// tagged as the key, the key is derived from the namespace and the set name of key
+// and the value of that field. Otherwise key is returned.
+func objectKey(key *Key, obj interface{}) (*Key, error) {
+	if key == nil {
+		return nil, NewAerospikeError(PARAMETER_ERROR, "Key is nil; the namespace and the set name of the object are taken from it")
+	}
+
+	s, ok := objectStruct(obj)
+	if !ok {
+		return key, nil
+	}
+
+	info := getObjectInfo(s.Type())
+	if info.keyField < 0 {
+		return key, nil
+	}
+
+	userKey, err := valueToInterface(s.Field(info.keyField), false)
+	if err != nil {
+		return nil, err
+	}
+	return NewKey(key.namespace, key.setName, userKey)
 }
 
-var objectMappings = &SyncMap{objectMappings: map[string]map[string]string{}, objectFields: map[string][]string{}}
+// objectWritePolicy returns a copy of the policy with the generation and the
+// expiration of the object's metadata fields. A non-zero generation is expected to
+// be the generation of the record, so writing an object read before a concurrent
+// update fails. A zero generation or expiration keeps the policy's values.
+func objectWritePolicy(policy *WritePolicy, obj interface{}) *WritePolicy {
+	s, ok := objectStruct(obj)
+	if !ok {
+		return policy
+	}
+
+	info := getObjectInfo(s.Type())
+	if info.genField < 0 && info.ttlField < 0 {
+		return policy
+	}
 
-func cacheObjectTags(obj reflect.Value) {
-	objType := obj.Type().Name()
-	// exit if already processed
-	if objectMappings.mappingExists(objType) {
-		return
+	res := *policy
+	if info.genField >= 0 {
+		if gen, ok := intFieldValue(s.Field(info.genField)); ok && gen != 0 {
+			res.Generation = int32(gen)
+			res.GenerationPolicy = EXPECT_GEN_EQUAL
+		}
 	}
 
-	mapping := map[string]string{}
-	fields := []string{}
+	if info.ttlField >= 0 {
+		if ttl, ok := intFieldValue(s.Field(info.ttlField)); ok && ttl != 0 {
+			res.Expiration = int32(ttl)
+		}
+	}
+	return &res
+}
 
-	typeOfT := obj.Type()
-	numFields := obj.NumField()
-	for i := 0; i < numFields; i++ {
-		f := typeOfT.Field(i)
-		// skip unexported fields
-		if f.PkgPath != "" {
+func intFieldValue(f reflect.Value) (int64, bool) {
+	switch f.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return f.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(f.Uint()), true
+	}
+	return 0, false
+}
+
+// setObjectMetadata sets the record's generation and expiration to the object's metadata fields.
+func setObjectMetadata(obj reflect.Value, generation, expiration int) error {
+	info := getObjectInfo(obj.Type())
+	if info.genField >= 0 {
+		if err := setValue(obj.Field(info.genField), generation); err != nil {
+			return err
+		}
+	}
+
+	if info.ttlField >= 0 {
+		if err := setValue(obj.Field(info.ttlField), expiration); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setObjectField sets the value of the bin to the struct field mapped to the bin.
+// Bins which are not mapped to a field are ignored.
+func setObjectField(obj reflect.Value, binName string, value interface{}) error {
+	if fld, exists := getObjectInfo(obj.Type()).byAlias[binName]; exists {
+		return setFieldValue(obj, fld, value)
+	}
+	return nil
+}
+
+// setFieldValue reverses fieldToInterface.
+func setFieldValue(s reflect.Value, fld *objectField, value interface{}) error {
+	f := s.Field(fld.index)
+	if !fld.json && !fld.msgpack {
+		return setValue(f, value)
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return typeMismatchError(f, value)
+	}
+
+	if fld.json {
+		return json.Unmarshal(b, f.Addr().Interface())
+	}
+
+	obj, err := newUnpacker(b, 0, len(b)).unpackObject()
+	if err != nil {
+		return err
+	}
+	return setValue(f, obj)
+}
+
+// setValue sets a value read from the server to f. It reverses valueToInterface,
+// allocating the pointers, slices, maps and structs of f's type as needed.
+func setValue(f reflect.Value, value interface{}) error {
+	if value == nil {
+		f.Set(reflect.Zero(f.Type()))
+		return nil
+	}
+
+	if f.CanAddr() && f.Addr().Type().Implements(valueUnmarshalerType) {
+		return f.Addr().Interface().(ValueUnmarshaler).UnmarshalValue(value)
+	}
+
+	switch f.Kind() {
+	case reflect.Ptr:
+		if f.IsNil() {
+			f.Set(reflect.New(f.Type().Elem()))
+		}
+		return setValue(f.Elem(), value)
+
+	case reflect.Interface:
+		rv := reflect.ValueOf(value)
+		if !rv.Type().AssignableTo(f.Type()) {
+			return typeMismatchError(f, value)
+		}
+		f.Set(rv)
+		return nil
+
+	case reflect.Bool:
+		if v, ok := objectToBool(value); ok {
+			f.SetBool(v)
+			return nil
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v, ok := objectToInt64(value); ok {
+			f.SetInt(v)
+			return nil
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v, ok := objectToUint64(value); ok {
+			f.SetUint(v)
+			return nil
+		}
+
+	case reflect.Float32, reflect.Float64:
+		if v, ok := objectToFloat64(value); ok {
+			f.SetFloat(v)
+			return nil
+		}
+
+	case reflect.String:
+		if v, ok := value.(string); ok {
+			f.SetString(v)
+			return nil
+		}
+
+	case reflect.Struct:
+		return setStruct(f, value)
+
+	case reflect.Slice:
+		if v, ok := value.([]byte); ok && f.Type().Elem().Kind() == reflect.Uint8 {
+			// the unpacker does not copy the blobs in lists and maps out of the buffer
+			f.SetBytes(append([]byte(nil), v...))
+			return nil
+		}
+
+		if f.Type() == mapPairsType {
+			if v, ok := objectToMapPairs(value); ok {
+				f.Set(reflect.ValueOf(v))
+				return nil
+			}
+		}
+
+		if v, ok := value.([]interface{}); ok {
+			s := reflect.MakeSlice(f.Type(), len(v), len(v))
+			for i := range v {
+				if err := setValue(s.Index(i), v[i]); err != nil {
+					return err
+				}
+			}
+			f.Set(s)
+			return nil
+		}
+
+	case reflect.Array:
+		if v, ok := value.([]byte); ok && f.Type().Elem().Kind() == reflect.Uint8 {
+			for i := 0; i < len(v) && i < f.Len(); i++ {
+				f.Index(i).SetUint(uint64(v[i]))
+			}
+			return nil
+		}
+
+		if v, ok := value.([]interface{}); ok {
+			for i := 0; i < len(v) && i < f.Len(); i++ {
+				if err := setValue(f.Index(i), v[i]); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+	case reflect.Map:
+		if v, ok := value.(map[interface{}]interface{}); ok {
+			m := reflect.MakeMap(f.Type())
+			for k, elem := range v {
+				newKey := reflect.New(f.Type().Key()).Elem()
+				if err := setValue(newKey, k); err != nil {
+					return err
+				}
+
+				newElem := reflect.New(f.Type().Elem()).Elem()
+				if err := setValue(newElem, elem); err != nil {
+					return err
+				}
+
+				m.SetMapIndex(newKey, newElem)
+			}
+			f.Set(m)
+			return nil
+		}
+	}
+
+	return typeMismatchError(f, value)
+}
+
+func setStruct(f reflect.Value, value interface{}) error {
+	// support time.Time
+	if f.Type().PkgPath() == "time" && f.Type().Name() == "Time" {
+		if v, ok := objectToInt64(value); ok {
+			f.Set(reflect.ValueOf(time.Unix(0, v)))
+			return nil
+		}
+		return typeMismatchError(f, value)
+	}
+
+	// support big.Int
+	if f.Type().PkgPath() == "math/big" && f.Type().Name() == "Int" {
+		if v, ok := objectToBigInt(value); ok {
+			f.Set(reflect.ValueOf(*v))
+			return nil
+		}
+		return typeMismatchError(f, value)
+	}
+
+	valMap, ok := value.(map[interface{}]interface{})
+	if !ok {
+		return typeMismatchError(f, value)
+	}
+
+	info := getObjectInfo(f.Type())
+	for k, elem := range valMap {
+		alias, ok := k.(string)
+		if !ok {
 			continue
 		}
 
-		tag := strings.Trim(f.Tag.Get(aerospikeTag), " ")
-		if tag != "-" {
-			if tag != "" {
-				mapping[tag] = f.Name
-				fields = append(fields, tag)
-			} else {
-				fields = append(fields, f.Name)
+		if fld, exists := info.byAlias[alias]; exists {
+			if err := setFieldValue(f, fld, elem); err != nil {
+				return err
 			}
 		}
 	}
+	return nil
+}
 
-	objectMappings.setMapping(objType, mapping, fields)
+func typeMismatchError(f reflect.Value, value interface{}) error {
+	return NewAerospikeError(PARSE_ERROR, fmt.Sprintf("Can not set value of type %T to %s", value, f.Type()))
+}
+
+// objectToMapPairs returns the pairs of an ordered map, or of a map in no particular order.
+func objectToMapPairs(value interface{}) ([]MapPair, bool) {
+	switch v := value.(type) {
+	case []MapPair:
+		return v, true
+	case map[interface{}]interface{}:
+		pairs := make([]MapPair, 0, len(v))
+		for key, val := range v {
+			pairs = append(pairs, MapPair{Key: key, Value: val})
+		}
+		return pairs, true
+	}
+	return nil, false
+}
+
+func objectToInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+// objectToUint64 returns the value of an uint64 field. Older versions of
+// the client stored uint64 fields as the int64 value of their bits.
+func objectToUint64(value interface{}) (uint64, bool) {
+	switch v := value.(type) {
+	case int:
+		return uint64(v), true
+	case int64:
+		return uint64(v), true
+	case uint64:
+		return v, true
+	case []byte:
+		return bytesToBigInt(v).Uint64(), true
+	}
+	return 0, false
+}
+
+// objectToFloat64 returns the value of a float field. Older versions of the
+// client stored float fields as the integer value of their bits.
+func objectToFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return math.Float64frombits(uint64(v)), true
+	}
+	return 0, false
+}
+
+// objectToBool returns the value of a bool field. Older versions of the
+// client stored bool fields as 1 and 0.
+func objectToBool(value interface{}) (bool, bool) {
+	switch v := value.(type) {
+	case bool:
+		return v, true
+	case int:
+		return v == 1, true
+	}
+	return false, false
+}
+
+// objectToBigInt returns the value of an integer field. Integers which do
+// not fit in int64 are stored as blobs, and in lists and maps, as uint64.
+func objectToBigInt(value interface{}) (*big.Int, bool) {
+	switch v := value.(type) {
+	case int:
+		return big.NewInt(int64(v)), true
+	case int64:
+		return big.NewInt(v), true
+	case uint64:
+		return new(big.Int).SetUint64(v), true
+	case []byte:
+		return bytesToBigInt(v), true
+	}
+	return nil, false
 }
@@ -0,0 +1,512 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"time"
+
+	ParticleType "github.com/aerospike/aerospike-client-go/types/particle_type"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type marshalInner struct {
+	Name  string
+	Score float64
+	When  time.Time
+	Tags  []string
+}
+
+type MarshalEmbedded struct {
+	Level int
+}
+
+type marshalObject struct {
+	MarshalEmbedded
+
+	Inner      marshalInner
+	InnerP     *marshalInner
+	InnerPP    **marshalInner
+	InnerNilP  *marshalInner
+	Inners     []marshalInner
+	InnerPs    []*marshalInner
+	InnerMap   map[string]marshalInner
+	IntMap     map[int][]int
+	Matrix     [][]int8
+	Array      [4]byte
+	Blob       []byte
+	Any        interface{}
+	Flag       bool
+	FlagP      *bool
+	U64        uint64
+	U64s       []uint64
+	F32        float32
+	Big        big.Int
+	BigP       *big.Int
+	Renamed    string `as:"other"`
+	Ignored    string `as:"-"`
+	unexported int
+}
+
+type testUUID [16]byte
+
+func (u testUUID) MarshalValue() (interface{}, error) {
+	return hex.EncodeToString(u[:]), nil
+}
+
+func (u *testUUID) UnmarshalValue(value interface{}) error {
+	b, err := hex.DecodeString(value.(string))
+	if err != nil {
+		return err
+	}
+	copy(u[:], b)
+	return nil
+}
+
+// testDecimal has no exported fields, and marshals itself with a pointer receiver.
+type testDecimal struct {
+	unscaled int64
+	scale    int
+}
+
+func (d *testDecimal) MarshalValue() (interface{}, error) {
+	if d.scale < 0 {
+		return nil, errors.New("invalid scale")
+	}
+	return []interface{}{d.unscaled, d.scale}, nil
+}
+
+func (d *testDecimal) UnmarshalValue(value interface{}) error {
+	l := value.([]interface{})
+	d.unscaled, d.scale = int64(l[0].(int)), l[1].(int)
+	return nil
+}
+
+type marshalerObject struct {
+	ID       testUUID
+	IDP      *testUUID
+	IDs      []testUUID
+	Price    testDecimal
+	Prices   map[string]*testDecimal
+	Nested   marshalInner
+	NilPrice *testDecimal
+}
+
+// testBinObject writes its bins itself.
+type testBinObject struct {
+	a, b int
+}
+
+func (o *testBinObject) MarshalBins() (BinMap, error) {
+	return BinMap{"sum": o.a + o.b, "diff": o.a - o.b}, nil
+}
+
+func (o *testBinObject) UnmarshalBins(bins BinMap) error {
+	sum, diff := bins["sum"].(int), bins["diff"].(int)
+	o.a, o.b = (sum+diff)/2, (sum-diff)/2
+	return nil
+}
+
+type taggedObject struct {
+	ID         string            `as:"id,key"`
+	Nick       string            `as:"nick,omitempty"`
+	Count      int               `as:",omitempty"`
+	Inner      *marshalInner     `as:"inner,omitempty"`
+	Attributes map[string]string `as:"attrs,json"`
+	Scores     []marshalInner    `as:"scores,msgpack"`
+	Generation uint32            `asm:"gen"`
+	Expiration int               `asm:"ttl"`
+}
+
+// roundTrip sends the object's bins through the wire encoding, and reads them back
+// into a new object.
+func roundTrip(obj interface{}, res interface{}) error {
+	bins, err := marshal(obj, false)
+	if err != nil {
+		return err
+	}
+
+	rv := reflect.Indirect(reflect.ValueOf(res).Elem())
+	for _, bin := range bins {
+		buf := make([]byte, bin.Value.estimateSize())
+		n, err := bin.Value.write(buf, 0)
+		Expect(err).ToNot(HaveOccurred())
+
+		value, err := bytesToParticle(bin.Value.GetType(), buf, 0, n)
+		Expect(err).ToNot(HaveOccurred())
+
+		if err := setObjectField(rv, bin.Name, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var _ = Describe("Marshalling Test", func() {
+
+	It("should read back the nested types identically", func() {
+		when := time.Unix(0, 1234567890)
+		flag := false
+		inner := &marshalInner{Name: "p", Score: 1.5, When: when}
+		huge, _ := new(big.Int).SetString("-123456789012345678901234567890", 10)
+
+		obj := &marshalObject{
+			MarshalEmbedded: MarshalEmbedded{Level: 3},
+
+			Inner:    marshalInner{Name: "a", Score: -2.25, When: when, Tags: []string{"x", "y"}},
+			InnerP:   inner,
+			InnerPP:  &inner,
+			Inners:   []marshalInner{{Name: "b"}, {Name: "c", Tags: []string{}}},
+			InnerPs:  []*marshalInner{inner, nil},
+			InnerMap: map[string]marshalInner{"d": {Name: "d", When: when}},
+			IntMap:   map[int][]int{1: {1, 2}, -1: nil},
+			Matrix:   [][]int8{{math.MinInt8}, {}, {math.MaxInt8}},
+			Array:    [4]byte{1, 2, 3, 4},
+			Blob:     []byte{5, 6},
+			Any:      "any",
+			Flag:     true,
+			FlagP:    &flag,
+			U64:      math.MaxUint64,
+			U64s:     []uint64{0, math.MaxUint64},
+			F32:      math.MaxFloat32,
+			Big:      *big.NewInt(math.MinInt64),
+			BigP:     huge,
+			Renamed:  "renamed",
+		}
+
+		res := &marshalObject{}
+		Expect(roundTrip(&obj, &res)).ToNot(HaveOccurred())
+		Expect(res).To(Equal(obj))
+	})
+
+	It("should persist the fields by their alias", func() {
+		obj := &marshalObject{Renamed: "renamed", Ignored: "ignored", unexported: 1}
+
+		bins, err := marshal(&obj, false)
+		Expect(err).ToNot(HaveOccurred())
+
+		names := []string{}
+		for _, bin := range bins {
+			names = append(names, bin.Name)
+		}
+		Expect(names).To(ContainElement("other"))
+		Expect(names).ToNot(ContainElement("Renamed"))
+		Expect(names).ToNot(ContainElement("Ignored"))
+		Expect(names).ToNot(ContainElement("unexported"))
+	})
+
+	It("should read the values stored by older versions of the client", func() {
+		res := &marshalObject{}
+		rv := reflect.ValueOf(res).Elem()
+
+		Expect(setObjectField(rv, "Flag", 1)).ToNot(HaveOccurred())
+		Expect(setObjectField(rv, "F32", int(math.Float64bits(1.5)))).ToNot(HaveOccurred())
+		Expect(setObjectField(rv, "U64", -1)).ToNot(HaveOccurred())
+
+		Expect(res.Flag).To(BeTrue())
+		Expect(res.F32).To(Equal(float32(1.5)))
+		Expect(res.U64).To(Equal(uint64(math.MaxUint64)))
+	})
+
+	It("should write the []MapPair fields as ordered maps, and read them back", func() {
+		obj := &struct{ Pairs []MapPair }{Pairs: []MapPair{{"z", 1}, {"a", 2}}}
+
+		bins, err := marshal(&obj, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bins[0].Value).To(BeAssignableToTypeOf(&OrderedMapValue{}))
+
+		res := &struct{ Pairs []MapPair }{}
+		rv := reflect.ValueOf(res).Elem()
+		Expect(setObjectField(rv, "Pairs", []MapPair{{"z", 1}, {"a", 2}})).ToNot(HaveOccurred())
+		Expect(res).To(Equal(obj))
+
+		Expect(setObjectField(rv, "Pairs", map[interface{}]interface{}{"a": 2})).ToNot(HaveOccurred())
+		Expect(res.Pairs).To(Equal([]MapPair{{"a", 2}}))
+	})
+
+	It("should return an error when the value does not fit the field", func() {
+		res := &marshalObject{}
+		rv := reflect.ValueOf(res).Elem()
+
+		Expect(setObjectField(rv, "Inner", "not a struct")).To(HaveOccurred())
+		Expect(setObjectField(rv, "Inners", []interface{}{1})).To(HaveOccurred())
+		Expect(setObjectField(rv, "unknown", 1)).ToNot(HaveOccurred())
+	})
+
+	Context("Tag options", func() {
+
+		binNames := func(obj interface{}) []string {
+			bins, err := marshal(obj, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			names := []string{}
+			for _, bin := range bins {
+				names = append(names, bin.Name)
+			}
+			return names
+		}
+
+		It("should omit the empty fields tagged with omitempty", func() {
+			obj := &taggedObject{ID: "a"}
+			Expect(binNames(&obj)).To(ConsistOf("id", "attrs"))
+
+			obj = &taggedObject{ID: "a", Nick: "n", Count: -1, Inner: &marshalInner{}}
+			Expect(binNames(&obj)).To(ConsistOf("id", "nick", "Count", "inner", "attrs"))
+		})
+
+		It("should store the fields tagged with json and msgpack as blobs, and read them back", func() {
+			obj := &taggedObject{
+				ID:         "a",
+				Attributes: map[string]string{"color": "red"},
+				Scores:     []marshalInner{{Name: "s", Score: 0.5, Tags: []string{"t"}}},
+			}
+
+			bins, err := marshal(&obj, false)
+			Expect(err).ToNot(HaveOccurred())
+			for _, bin := range bins {
+				switch bin.Name {
+				case "attrs":
+					Expect(bin.Value.GetObject()).To(Equal([]byte(`{"color":"red"}`)))
+				case "scores":
+					Expect(bin.Value.GetType()).To(Equal(ParticleType.BLOB))
+				}
+			}
+
+			res := &taggedObject{}
+			Expect(roundTrip(&obj, &res)).ToNot(HaveOccurred())
+			Expect(res).To(Equal(obj))
+
+			rv := reflect.ValueOf(res).Elem()
+			Expect(setObjectField(rv, "attrs", "not a blob")).To(HaveOccurred())
+		})
+
+		It("should not persist the metadata fields, and set them from the record", func() {
+			obj := &taggedObject{ID: "a", Generation: 3, Expiration: 60}
+			Expect(binNames(&obj)).To(ConsistOf("id", "attrs"))
+
+			res := &taggedObject{}
+			Expect(setObjectMetadata(reflect.ValueOf(res).Elem(), 5, 100)).ToNot(HaveOccurred())
+			Expect(res.Generation).To(Equal(uint32(5)))
+			Expect(res.Expiration).To(Equal(100))
+		})
+
+		It("should set the policy's generation and expiration from the metadata fields", func() {
+			policy := NewWritePolicy(1, 10)
+
+			res := objectWritePolicy(policy, &taggedObject{Generation: 3})
+			Expect(res.Generation).To(Equal(int32(3)))
+			Expect(res.GenerationPolicy).To(Equal(EXPECT_GEN_EQUAL))
+			Expect(res.Expiration).To(Equal(int32(10)))
+
+			res = objectWritePolicy(policy, &taggedObject{})
+			Expect(res.Generation).To(Equal(int32(1)))
+			Expect(res.GenerationPolicy).To(Equal(NONE))
+
+			res = objectWritePolicy(policy, &taggedObject{Generation: 3, Expiration: 60})
+			Expect(res.Expiration).To(Equal(int32(60)))
+
+			// the policy itself is not modified
+			Expect(policy.Generation).To(Equal(int32(1)))
+			Expect(objectWritePolicy(policy, &marshalObject{})).To(BeIdenticalTo(policy))
+		})
+
+		It("should derive the key from the field tagged with key", func() {
+			key, err := NewObjectKey("test", "set", &taggedObject{ID: "a"})
+			Expect(err).ToNot(HaveOccurred())
+
+			expected, _ := NewKey("test", "set", "a")
+			Expect(key.Digest()).To(Equal(expected.Digest()))
+
+			_, err = NewObjectKey("test", "set", &marshalObject{})
+			Expect(err).To(HaveOccurred())
+
+			_, err = NewObjectKey("test", "set", 1)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should derive the key of PutObject from the passed key and the key field", func() {
+			passed, _ := NewKey("test", "set", "b")
+
+			key, err := objectKey(passed, &taggedObject{ID: "a"})
+			Expect(err).ToNot(HaveOccurred())
+			expected, _ := NewKey("test", "set", "a")
+			Expect(key.Digest()).To(Equal(expected.Digest()))
+			Expect(key.Namespace()).To(Equal("test"))
+
+			key, err = objectKey(passed, &marshalObject{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(key).To(BeIdenticalTo(passed))
+
+			_, err = objectKey(nil, &taggedObject{ID: "a"})
+			Expect(err).To(HaveOccurred())
+		})
+
+	})
+
+	Context("Generated marshalers", func() {
+
+		It("should write the same bins as reflection", func() {
+			bins, err := marshal(newBenchObject(), true)
+			Expect(err).ToNot(HaveOccurred())
+
+			genBins, err := newBenchGenObject().ToBins()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(benchBinMap(genBins)).To(Equal(benchBinMap(bins)))
+
+			// booleans are stored as integers by default
+			bins, err = marshal(newBenchObject(), false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(benchBinMap(bins)["Active"]).To(Equal(1))
+
+			boolBinsToIntegers(genBins)
+			Expect(benchBinMap(genBins)).To(Equal(benchBinMap(bins)))
+
+			bins, err = marshal(&benchObject{}, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			genBins, err = (&benchGenObject{}).ToBins()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(benchBinMap(genBins)).To(Equal(benchBinMap(bins)))
+		})
+
+		It("should read back the bins written by reflection", func() {
+			obj := newBenchObject()
+			obj.Total = math.MaxUint64
+			obj.Nick = "jack"
+
+			res := &benchGenObject{}
+			bins, err := marshal(obj, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.FromBins(benchBinMap(bins))).ToNot(HaveOccurred())
+			Expect(*res).To(Equal(benchGenObject(*obj)))
+
+			Expect(res.FromBins(BinMap{"Age": "not an int"})).To(HaveOccurred())
+		})
+
+		It("should handle the metadata fields like reflection", func() {
+			obj := newBenchObject()
+			obj.Generation, obj.TTL = 3, 300
+			genObj := benchGenObject(*obj)
+
+			policy := NewWritePolicy(0, 100)
+			Expect(genObj.MetadataWritePolicy(policy)).To(Equal(objectWritePolicy(policy, obj)))
+
+			obj.TTL, genObj.TTL = 0, 0
+			Expect(genObj.MetadataWritePolicy(policy)).To(Equal(objectWritePolicy(policy, obj)))
+
+			obj.Generation, genObj.Generation = 0, 0
+			Expect(genObj.MetadataWritePolicy(policy)).To(Equal(objectWritePolicy(policy, obj)))
+			Expect(policy.Generation).To(Equal(int32(0)))
+
+			Expect(setObjectMetadata(reflect.ValueOf(obj).Elem(), 5, 500)).ToNot(HaveOccurred())
+			genObj.SetMetadata(5, 500)
+			Expect(genObj).To(Equal(benchGenObject(*obj)))
+
+			Expect(objectBinNames(obj)).To(Equal([]string{"name", "nick", "Age", "Count", "Total", "Score", "Active", "Blob", "Created", "attrs"}))
+		})
+
+	})
+
+	Context("Marshalers", func() {
+
+		It("should convert the ValueMarshaler fields, and read them back with ValueUnmarshaler", func() {
+			id := testUUID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+			obj := &marshalerObject{
+				ID:     id,
+				IDP:    &id,
+				IDs:    []testUUID{id, {}},
+				Price:  testDecimal{unscaled: 12345, scale: 2},
+				Prices: map[string]*testDecimal{"eur": {unscaled: -1, scale: 0}},
+				Nested: marshalInner{Name: "n"},
+			}
+
+			bins, err := marshal(&obj, false)
+			Expect(err).ToNot(HaveOccurred())
+			for _, bin := range bins {
+				if bin.Name == "ID" {
+					Expect(bin.Value.GetObject()).To(Equal("0102030405060708090a0b0c0d0e0f10"))
+				}
+			}
+
+			res := &marshalerObject{}
+			Expect(roundTrip(&obj, &res)).ToNot(HaveOccurred())
+			Expect(res).To(Equal(obj))
+		})
+
+		It("should return the errors of the ValueMarshalers", func() {
+			obj := &marshalerObject{Prices: map[string]*testDecimal{"eur": {scale: -1}}}
+
+			_, err := marshal(&obj, false)
+			Expect(err).To(MatchError("invalid scale"))
+		})
+
+		It("should return a value which fails to write when the ValueMarshaler fails in NewValue", func() {
+			vl := NewValue(&testDecimal{scale: -1})
+
+			_, err := vl.write(make([]byte, vl.estimateSize()), 0)
+			Expect(err).To(MatchError("invalid scale"))
+			Expect(vl.pack(newPacker())).To(MatchError("invalid scale"))
+
+			_, err = NewKey("test", "test", vl)
+			Expect(err).To(MatchError("invalid scale"))
+
+			bin := NewBin("bin", &testDecimal{scale: -1})
+			_, err = bin.Value.write(nil, 0)
+			Expect(err).To(MatchError("invalid scale"))
+		})
+
+		It("should honor ValueMarshaler in NewValue and in lists", func() {
+			id := testUUID{0xff}
+			Expect(NewValue(id).GetObject()).To(Equal("ff000000000000000000000000000000"))
+
+			packer := newPacker()
+			Expect(packer.PackObject([]interface{}{&testDecimal{unscaled: 1, scale: 2}})).ToNot(HaveOccurred())
+
+			obj, err := newUnpacker(packer.buffer.Bytes(), 0, packer.buffer.Len()).UnpackList()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj).To(Equal([]interface{}{[]interface{}{1, 2}}))
+		})
+
+		It("should write and read the bins of BinMarshaler and BinUnmarshaler objects", func() {
+			obj := &testBinObject{a: 5, b: 3}
+
+			bins, err := marshal(&obj, false)
+			Expect(err).ToNot(HaveOccurred())
+
+			binMap := BinMap{}
+			for _, bin := range bins {
+				binMap[bin.Name] = bin.Value.GetObject()
+			}
+			Expect(binMap).To(Equal(BinMap{"sum": 8, "diff": 2}))
+
+			res := &testBinObject{}
+			bu, ok := implementation(&res, binUnmarshalerType)
+			Expect(ok).To(BeTrue())
+			Expect(bu.(BinUnmarshaler).UnmarshalBins(binMap)).ToNot(HaveOccurred())
+			Expect(res).To(Equal(obj))
+
+			_, ok = implementation(&marshalObject{}, binUnmarshalerType)
+			Expect(ok).To(BeFalse())
+		})
+
+	})
+
+})
@@ -41,6 +41,9 @@ type Node struct {
 	connections *AtomicQueue //ArrayBlockingQueue<*Connection>
 	health      *AtomicInt   //AtomicInteger
 
+	// login session used to authenticate new connections
+	session *session
+
 	partitionGeneration int
 	refreshCount        int
 	referenceCount      int
@@ -64,6 +67,7 @@ func newNode(cluster *Cluster, nv *nodeValidator) *Node {
 		host:                nv.aliases[0],
 		connections:         NewAtomicQueue(cluster.connectionQueueSize),
 		health:              NewAtomicInt(_FULL_HEALTH),
+		session:             nv.session,
 		partitionGeneration: -1,
 		referenceCount:      0,
 		responded:           false,
@@ -77,6 +81,8 @@ func (nd *Node) Refresh() ([]*Host, error) {
 
 	nd.refreshCount++
 
+	nd.refreshSession()
+
 	conn, err := nd.GetConnection(1 * time.Second)
 	if err != nil {
 		return nil, err
@@ -197,14 +203,35 @@ func (nd *Node) GetConnection(timeout time.Duration) (conn *Connection, err erro
 		conn.Close()
 	}
 
-	if conn, err = NewConnection(nd.address, nd.cluster.connectionTimeout); err != nil {
+	conn, err = nd.newConnection()
+	if isSessionRejected(err) {
+		// the session was cleared; log in again on a new connection
+		conn, err = nd.newConnection()
+	}
+	if isCredentialRejected(err) && nd.cluster.refreshCredentials() {
+		// the password may have been rotated; retry with the new credentials
+		conn, err = nd.newConnection()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if conn.SetTimeout(timeout) != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// newConnection opens a new connection to the node and authenticates it.
+func (nd *Node) newConnection() (*Connection, error) {
+	conn, err := NewConnection(nd.address, nd.cluster.connectionTimeout)
+	if err != nil {
 		return nil, err
 	}
 
 	// need to authenticate
-	if nd.cluster.user != "" {
-		command := newAdminCommand()
-		if err := command.authenticate(conn, nd.cluster.user, nd.cluster.password); err != nil {
+	if nd.cluster.requiresAuthentication() {
+		if err := nd.session.authenticate(nd.cluster, conn); err != nil {
 			// Socket not authenticated. Do not put back into pool.
 			conn.Close()
 
@@ -212,12 +239,30 @@ func (nd *Node) GetConnection(timeout time.Duration) (conn *Connection, err erro
 		}
 	}
 
-	if conn.SetTimeout(timeout) != nil {
-		return nil, err
-	}
 	return conn, nil
 }
 
+// refreshSession logs in again before the session expires on the server,
+// so that new connections do not have to wait for the login.
+func (nd *Node) refreshSession() {
+	if !nd.cluster.requiresAuthentication() || !nd.session.expired() {
+		return
+	}
+
+	conn, err := NewConnection(nd.address, nd.cluster.connectionTimeout)
+	if err != nil {
+		Logger.Warn("Node `%s` session refresh failed: %s", nd, err)
+		return
+	}
+
+	if err := nd.session.login(nd.cluster, conn); err != nil {
+		conn.Close()
+		Logger.Warn("Node `%s` session refresh failed: %s", nd, err)
+		return
+	}
+	nd.PutConnection(conn)
+}
+
 // PutConnection puts back a connection to the pool.
 // If connection pool is full, the connection will be
 // closed and discarded.
@@ -31,6 +31,9 @@ type nodeValidator struct {
 	address    string
 	useNewInfo bool //= true
 	cluster    *Cluster
+
+	// login session established while validating the node
+	session *session
 }
 
 // Generates a node validator
@@ -38,6 +41,7 @@ func newNodeValidator(cluster *Cluster, host *Host, timeout time.Duration) (*nod
 	newNodeValidator := &nodeValidator{
 		useNewInfo: true,
 		cluster:    cluster,
+		session:    newSession(),
 	}
 
 	if err := newNodeValidator.setAliases(host); err != nil {
@@ -76,9 +80,19 @@ func (ndv *nodeValidator) setAddress(timeout time.Duration) error {
 		defer conn.Close()
 
 		// need to authenticate
-		if ndv.cluster.user != "" {
-			command := newAdminCommand()
-			if err := command.authenticate(conn, ndv.cluster.user, ndv.cluster.password); err != nil {
+		if ndv.cluster.requiresAuthentication() {
+			err := ndv.session.login(ndv.cluster, conn)
+			if isCredentialRejected(err) && ndv.cluster.refreshCredentials() {
+				// the password may have been rotated; retry with the new credentials
+				conn.Close()
+				if conn, err = NewConnection(address, time.Second); err != nil {
+					return err
+				}
+				defer conn.Close()
+
+				err = ndv.session.login(ndv.cluster, conn)
+			}
+			if err != nil {
 				// Socket not authenticated. Do not put back into pool.
 				conn.Close()
 				return err
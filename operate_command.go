@@ -22,8 +22,11 @@ type operateCommand struct {
 }
 
 func newOperateCommand(cluster *Cluster, policy *WritePolicy, key *Key, operations []*Operation) *operateCommand {
+	readCommand := newReadCommand(cluster, policy, key, nil)
+	readCommand.isOperation = true
+
 	return &operateCommand{
-		readCommand: newReadCommand(cluster, policy, key, nil),
+		readCommand: readCommand,
 		policy:      policy,
 		operations:  operations,
 	}
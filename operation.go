@@ -20,13 +20,20 @@ type OperationType byte
 const (
 	READ OperationType = 1
 	// READ_HEADER OperationType = 1
-	WRITE   OperationType = 2
-	ADD     OperationType = 5
-	APPEND  OperationType = 9
-	PREPEND OperationType = 10
-	TOUCH   OperationType = 11
+	WRITE      OperationType = 2
+	CDT_READ   OperationType = 3
+	CDT_MODIFY OperationType = 4
+	ADD        OperationType = 5
+	APPEND     OperationType = 9
+	PREPEND    OperationType = 10
+	TOUCH      OperationType = 11
 )
 
+// OpResults contains the results of the operations on a bin, in the order
+// of the operations. Operate returns it as the value of a bin when more
+// than one operation on the bin returns a result.
+type OpResults []interface{}
+
 // Operation contasins operation definition.
 // This struct is used in client's operate() method.
 type Operation struct {
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 	"time"
 
@@ -25,6 +26,22 @@ import (
 	Buffer "github.com/aerospike/aerospike-client-go/utils/buffer"
 )
 
+// Packer serializes values using MessagePack, in the Pack method of CustomValue.
+type Packer interface {
+	PackNil()
+	PackBool(val bool)
+	PackALong(val int64)
+	PackAULong(val uint64)
+	PackFloat64(val float64)
+	PackString(val string)
+	PackBytes(b []byte)
+	PackArrayBegin(size int)
+	PackMapBegin(size int)
+
+	// PackObject packs any value supported by NewValue.
+	PackObject(obj interface{}) error
+}
+
 type packer struct {
 	buffer *bytes.Buffer
 	offset int
@@ -54,6 +71,14 @@ func packAnyMap(val map[interface{}]interface{}) ([]byte, error) {
 	return packer.buffer.Bytes(), nil
 }
 
+func packOrderedMap(pairs []MapPair, order mapOrderType) ([]byte, error) {
+	packer := newPacker()
+	if err := packer.packMapPairs(pairs, order); err != nil {
+		return nil, nil
+	}
+	return packer.buffer.Bytes(), nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 func newPacker() *packer {
@@ -107,6 +132,35 @@ func (pckr *packer) PackMap(theMap map[interface{}]interface{}) error {
 	return nil
 }
 
+// PackOrderedMap packs the pairs as a map, in their order.
+func (pckr *packer) PackOrderedMap(pairs []MapPair) error {
+	return pckr.packMapPairs(pairs, MapOrder.UNORDERED)
+}
+
+// packMapPairs packs the pairs as a map, in their order. Maps which are not
+// UNORDERED start with an entry describing the order, as the server stores them.
+func (pckr *packer) packMapPairs(pairs []MapPair, order mapOrderType) error {
+	if order == MapOrder.UNORDERED {
+		pckr.PackMapBegin(len(pairs))
+	} else {
+		pckr.PackMapBegin(len(pairs) + 1)
+		pckr.PackAByte(0xc7)
+		pckr.PackAByte(0)
+		pckr.PackAByte(byte(order))
+		pckr.PackNil()
+	}
+
+	for i := range pairs {
+		if err := pckr.PackObject(pairs[i].Key); err != nil {
+			return err
+		}
+		if err := pckr.PackObject(pairs[i].Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (pckr *packer) PackMapBegin(size int) {
 	if size < 16 {
 		pckr.PackAByte(0x80 | byte(size))
@@ -137,6 +191,14 @@ func (pckr *packer) PackObject(obj interface{}) error {
 	switch v := obj.(type) {
 	case Value:
 		return v.pack(pckr)
+	case CustomValue:
+		return v.Pack(pckr)
+	case ValueMarshaler:
+		obj, err := v.MarshalValue()
+		if err != nil {
+			return err
+		}
+		return pckr.PackObject(obj)
 	case string:
 		pckr.PackString(v)
 		return nil
@@ -174,6 +236,7 @@ func (pckr *packer) PackObject(obj interface{}) error {
 			return nil
 		}
 		pckr.PackAULong(uint64(v))
+		return nil
 	case int64:
 		pckr.PackALong(v)
 		return nil
@@ -183,6 +246,10 @@ func (pckr *packer) PackObject(obj interface{}) error {
 	case time.Time:
 		pckr.PackALong(v.UnixNano())
 		return nil
+	case *big.Int:
+		return NewBigIntValue(v).pack(pckr)
+	case big.Int:
+		return NewBigIntValue(&v).pack(pckr)
 	case nil:
 		pckr.PackNil()
 		return nil
@@ -199,6 +266,8 @@ func (pckr *packer) PackObject(obj interface{}) error {
 		return pckr.PackList(obj.([]interface{}))
 	case map[interface{}]interface{}:
 		return pckr.PackMap(obj.(map[interface{}]interface{}))
+	case []MapPair:
+		return pckr.PackOrderedMap(v)
 	}
 
 	// check for array and map
@@ -20,6 +20,8 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	ParticleType "github.com/aerospike/aerospike-client-go/types/particle_type"
 )
 
 func testPackingFor(v interface{}) interface{} {
@@ -404,5 +406,82 @@ var _ = Describe("Packing Test", func() {
 			Expect(testPackingFor(vFloat64)).To(Equal(retFloat64))
 			Expect(testPackingFor(vStr)).To(Equal(retStr))
 		})
+
+		It("should skip the order header of ordered maps", func() {
+			// {<ext 0, 1 byte>: nil, 1: "a"}
+			buf := []byte{0x82, 0xd4, 0x00, 0x01, 0xc0, 0x01, 0xa2, 0x03, 'a'}
+
+			unpacker := newUnpacker(buf, 0, len(buf))
+			unpackedValue, err := unpacker.unpackObject()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unpackedValue).To(Equal(map[interface{}]interface{}{1: "a"}))
+			Expect(unpacker.offset).To(Equal(len(buf)))
+		})
+
+		It("should pack ordered maps in their order, and unpack them in order", func() {
+			pairs := []MapPair{{"b", 1}, {"a", []MapPair{{2, "x"}, {1, "y"}}}, {nil, "z"}}
+
+			packer := newPacker()
+			Expect(packer.PackObject(pairs)).ToNot(HaveOccurred())
+			buf := packer.buffer.Bytes()
+			Expect(buf[:4]).To(Equal([]byte{0x83, 0xa2, 0x03, 'b'}))
+
+			unpacked, err := newUnpacker(buf, 0, len(buf)).UnpackOrderedMap()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unpacked).To(Equal(pairs))
+
+			m, err := newUnpacker(buf, 0, len(buf)).UnpackMap()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(m).To(Equal(map[interface{}]interface{}{
+				"b": 1,
+				"a": map[interface{}]interface{}{2: "x", 1: "y"},
+				nil: "z",
+			}))
+		})
+
+		It("should unpack the maps in lists in order", func() {
+			list := []interface{}{1, []MapPair{{"b", 1}, {"a", []interface{}{[]MapPair{{2, "x"}, {1, "y"}}}}}}
+
+			packer := newPacker()
+			Expect(packer.PackObject(list)).ToNot(HaveOccurred())
+			buf := packer.buffer.Bytes()
+
+			unpacked, err := decodeParticle(ParticleType.LIST, buf, 0, len(buf), true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unpacked).To(Equal(list))
+
+			unpacked, err = decodeParticle(ParticleType.LIST, buf, 0, len(buf), false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unpacked).To(Equal([]interface{}{1, map[interface{}]interface{}{
+				"b": 1,
+				"a": []interface{}{map[interface{}]interface{}{2: "x", 1: "y"}},
+			}}))
+		})
+
+		It("should pack the order header of key ordered maps", func() {
+			pairs := []MapPair{{"a", 1}, {"b", 2}}
+
+			v := NewOrderedMapValueWithOrder(pairs, MapOrder.KEY_ORDERED)
+			buf := make([]byte, v.estimateSize())
+			_, err := v.write(buf, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf[:5]).To(Equal([]byte{0x83, 0xc7, 0x00, byte(MapOrder.KEY_ORDERED), 0xc0}))
+
+			unpacked, err := newUnpacker(buf, 0, len(buf)).UnpackOrderedMap()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unpacked).To(Equal(pairs))
+
+			buf, err = packOrderedMap(pairs, MapOrder.UNORDERED)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(buf[0]).To(Equal(byte(0x82)))
+		})
+
+		It("should skip the order header of ordered maps unpacked in order", func() {
+			buf := []byte{0x82, 0xd4, 0x00, 0x01, 0xc0, 0x01, 0xa2, 0x03, 'a'}
+
+			unpacked, err := newUnpacker(buf, 0, len(buf)).UnpackOrderedMap()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(unpacked).To(Equal([]MapPair{{1, "a"}}))
+		})
 	})
 })
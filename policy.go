@@ -53,6 +53,18 @@ type BasePolicy struct {
 	// SleepBetweenReplies determines duration to sleep between retries if a transaction fails and the
 	// timeout was not exceeded.  Enter zero to skip sleep.
 	SleepBetweenRetries time.Duration //= 500ms;
+
+	// OrderedMaps determines whether maps, in map bins and nested in list bins, are returned
+	// as []MapPair in their stored order, which is meaningful for key ordered maps, instead
+	// of map[interface{}]interface{}.
+	// Objects read by GetObject are not affected.
+	OrderedMaps bool //= false
+
+	// LazyBins determines whether list and map bins are kept undecoded until they are
+	// accessed with Record.Bin or Record.DecodeBins, which saves the decoding of bins
+	// that are never used. Such bins are missing from Record.Bins until then.
+	// Objects read by GetObject are not affected.
+	LazyBins bool //= false
 }
 
 // NewPolicy generates a new BasePolicy instance with default values.
@@ -0,0 +1,430 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"fmt"
+
+	Buffer "github.com/aerospike/aerospike-client-go/utils/buffer"
+)
+
+// PredExp represents a predicate expression which is evaluated on the server
+// for each record selected by a query or a scan. Only the records for which
+// the expression evaluates to true are returned.
+//
+// Predicate expressions are listed in postfix notation: the operands
+// come first, followed by the operator. For example, to filter the
+// records whose "age" bin is between 18 and 65:
+//
+//	stmt.SetPredExp(
+//		NewPredExpIntegerBin("age"),
+//		NewPredExpIntegerValue(18),
+//		NewPredExpIntegerGreaterEq(),
+//		NewPredExpIntegerBin("age"),
+//		NewPredExpIntegerValue(65),
+//		NewPredExpIntegerLessEq(),
+//		NewPredExpAnd(2),
+//	)
+//
+// Predicate expressions are only supported by Aerospike 3.12+ servers.
+type PredExp interface {
+	String() string
+
+	estimateSize() int
+	write(buf []byte, offset int) (int, error)
+}
+
+// wire protocol tags of the predicate expressions
+const (
+	_AS_PREDEXP_AND uint16 = 1
+	_AS_PREDEXP_OR  uint16 = 2
+	_AS_PREDEXP_NOT uint16 = 3
+
+	_AS_PREDEXP_INTEGER_VALUE uint16 = 10
+	_AS_PREDEXP_STRING_VALUE  uint16 = 11
+
+	_AS_PREDEXP_INTEGER_BIN uint16 = 100
+	_AS_PREDEXP_STRING_BIN  uint16 = 101
+	_AS_PREDEXP_LIST_BIN    uint16 = 103
+	_AS_PREDEXP_MAP_BIN     uint16 = 104
+
+	_AS_PREDEXP_INTEGER_VAR uint16 = 120
+	_AS_PREDEXP_STRING_VAR  uint16 = 121
+
+	_AS_PREDEXP_REC_DEVICE_SIZE   uint16 = 150
+	_AS_PREDEXP_REC_LAST_UPDATE   uint16 = 151
+	_AS_PREDEXP_REC_VOID_TIME     uint16 = 152
+	_AS_PREDEXP_REC_DIGEST_MODULO uint16 = 153
+
+	_AS_PREDEXP_INTEGER_EQUAL     uint16 = 200
+	_AS_PREDEXP_INTEGER_UNEQUAL   uint16 = 201
+	_AS_PREDEXP_INTEGER_GREATER   uint16 = 202
+	_AS_PREDEXP_INTEGER_GREATEREQ uint16 = 203
+	_AS_PREDEXP_INTEGER_LESS      uint16 = 204
+	_AS_PREDEXP_INTEGER_LESSEQ    uint16 = 205
+
+	_AS_PREDEXP_STRING_EQUAL   uint16 = 210
+	_AS_PREDEXP_STRING_UNEQUAL uint16 = 211
+	_AS_PREDEXP_STRING_REGEX   uint16 = 212
+
+	_AS_PREDEXP_LIST_ITERATE_OR    uint16 = 250
+	_AS_PREDEXP_MAPKEY_ITERATE_OR  uint16 = 251
+	_AS_PREDEXP_MAPVAL_ITERATE_OR  uint16 = 252
+	_AS_PREDEXP_LIST_ITERATE_AND   uint16 = 253
+	_AS_PREDEXP_MAPKEY_ITERATE_AND uint16 = 254
+	_AS_PREDEXP_MAPVAL_ITERATE_AND uint16 = 255
+)
+
+// RegexFlags determine the regular expression compile flags
+// for NewPredExpStringRegex. The flags can be or'ed together.
+type RegexFlags int
+
+const (
+	// REGEX_NONE uses the default POSIX basic regular expression syntax.
+	REGEX_NONE RegexFlags = 0
+	// REGEX_EXTENDED uses the POSIX extended regular expression syntax.
+	REGEX_EXTENDED RegexFlags = 1
+	// REGEX_ICASE ignores the case of the letters.
+	REGEX_ICASE RegexFlags = 2
+	// REGEX_NOSUB does not report the position of the matches.
+	REGEX_NOSUB RegexFlags = 4
+	// REGEX_NEWLINE makes match-any-character operators not match a newline.
+	REGEX_NEWLINE RegexFlags = 8
+)
+
+// tag(2) + length(4)
+const _PREDEXP_HEADER_SIZE = 6
+
+func writePredExpHeader(buf []byte, offset int, tag uint16, size int) int {
+	Buffer.Int16ToBytes(int16(tag), buf, offset)
+	Buffer.Int32ToBytes(int32(size), buf, offset+2)
+	return offset + _PREDEXP_HEADER_SIZE
+}
+
+func estimatePredExpSize(predExp []PredExp) int {
+	size := 0
+	for _, pred := range predExp {
+		size += pred.estimateSize()
+	}
+	return size
+}
+
+// predExpOp represents the expressions without a payload:
+// NOT, the comparisons and the record metadata.
+type predExpOp struct {
+	tag  uint16
+	name string
+}
+
+func (e *predExpOp) String() string {
+	return e.name
+}
+
+func (e *predExpOp) estimateSize() int {
+	return _PREDEXP_HEADER_SIZE
+}
+
+func (e *predExpOp) write(buf []byte, offset int) (int, error) {
+	return writePredExpHeader(buf, offset, e.tag, 0), nil
+}
+
+// predExpAndOr represents the AND and OR expressions.
+type predExpAndOr struct {
+	tag  uint16
+	nexp uint16
+}
+
+// NewPredExpAnd creates an AND predicate expression over the
+// preceding nexp expressions.
+func NewPredExpAnd(nexp uint16) PredExp {
+	return &predExpAndOr{tag: _AS_PREDEXP_AND, nexp: nexp}
+}
+
+// NewPredExpOr creates an OR predicate expression over the
+// preceding nexp expressions.
+func NewPredExpOr(nexp uint16) PredExp {
+	return &predExpAndOr{tag: _AS_PREDEXP_OR, nexp: nexp}
+}
+
+func (e *predExpAndOr) String() string {
+	if e.tag == _AS_PREDEXP_AND {
+		return fmt.Sprintf("AND(%d)", e.nexp)
+	}
+	return fmt.Sprintf("OR(%d)", e.nexp)
+}
+
+func (e *predExpAndOr) estimateSize() int {
+	return _PREDEXP_HEADER_SIZE + 2
+}
+
+func (e *predExpAndOr) write(buf []byte, offset int) (int, error) {
+	offset = writePredExpHeader(buf, offset, e.tag, 2)
+	Buffer.Int16ToBytes(int16(e.nexp), buf, offset)
+	return offset + 2, nil
+}
+
+// NewPredExpNot creates a NOT predicate expression which negates
+// the preceding expression.
+func NewPredExpNot() PredExp {
+	return &predExpOp{tag: _AS_PREDEXP_NOT, name: "NOT"}
+}
+
+// predExpIntegerValue represents an integer value.
+type predExpIntegerValue struct {
+	value int64
+}
+
+// NewPredExpIntegerValue creates an integer value predicate expression.
+func NewPredExpIntegerValue(value int64) PredExp {
+	return &predExpIntegerValue{value: value}
+}
+
+func (e *predExpIntegerValue) String() string {
+	return fmt.Sprintf("%d", e.value)
+}
+
+func (e *predExpIntegerValue) estimateSize() int {
+	return _PREDEXP_HEADER_SIZE + 8
+}
+
+func (e *predExpIntegerValue) write(buf []byte, offset int) (int, error) {
+	offset = writePredExpHeader(buf, offset, _AS_PREDEXP_INTEGER_VALUE, 8)
+	Buffer.Int64ToBytes(e.value, buf, offset)
+	return offset + 8, nil
+}
+
+// predExpString represents the expressions with a string payload:
+// string values, bins, variables and iterations.
+type predExpString struct {
+	tag   uint16
+	value string
+	name  string
+}
+
+// NewPredExpStringValue creates a string value predicate expression.
+func NewPredExpStringValue(value string) PredExp {
+	return &predExpString{tag: _AS_PREDEXP_STRING_VALUE, value: value, name: "'%s'"}
+}
+
+// NewPredExpIntegerBin creates a predicate expression for the value of an integer bin.
+func NewPredExpIntegerBin(name string) PredExp {
+	return &predExpString{tag: _AS_PREDEXP_INTEGER_BIN, value: name, name: "$%s"}
+}
+
+// NewPredExpStringBin creates a predicate expression for the value of a string bin.
+func NewPredExpStringBin(name string) PredExp {
+	return &predExpString{tag: _AS_PREDEXP_STRING_BIN, value: name, name: "$%s"}
+}
+
+// NewPredExpListBin creates a predicate expression for the value of a list bin.
+// It is used as the source of the list iteration expressions.
+func NewPredExpListBin(name string) PredExp {
+	return &predExpString{tag: _AS_PREDEXP_LIST_BIN, value: name, name: "$%s"}
+}
+
+// NewPredExpMapBin creates a predicate expression for the value of a map bin.
+// It is used as the source of the map iteration expressions.
+func NewPredExpMapBin(name string) PredExp {
+	return &predExpString{tag: _AS_PREDEXP_MAP_BIN, value: name, name: "$%s"}
+}
+
+// NewPredExpIntegerVar creates a predicate expression for an integer
+// iteration variable.
+func NewPredExpIntegerVar(name string) PredExp {
+	return &predExpString{tag: _AS_PREDEXP_INTEGER_VAR, value: name, name: "%s"}
+}
+
+// NewPredExpStringVar creates a predicate expression for a string
+// iteration variable.
+func NewPredExpStringVar(name string) PredExp {
+	return &predExpString{tag: _AS_PREDEXP_STRING_VAR, value: name, name: "%s"}
+}
+
+// NewPredExpListIterateOr creates a predicate expression which is true if the
+// preceding expression is true for any element of the preceding list bin.
+// The element is available to the expression through the named variable.
+func NewPredExpListIterateOr(varName string) PredExp {
+	return &predExpString{tag: _AS_PREDEXP_LIST_ITERATE_OR, value: varName, name: "LIST_ITERATE_OR using %s:"}
+}
+
+// NewPredExpListIterateAnd creates a predicate expression which is true if the
+// preceding expression is true for all elements of the preceding list bin.
+// The element is available to the expression through the named variable.
+func NewPredExpListIterateAnd(varName string) PredExp {
+	return &predExpString{tag: _AS_PREDEXP_LIST_ITERATE_AND, value: varName, name: "LIST_ITERATE_AND using %s:"}
+}
+
+// NewPredExpMapKeyIterateOr creates a predicate expression which is true if the
+// preceding expression is true for any key of the preceding map bin.
+// The key is available to the expression through the named variable.
+func NewPredExpMapKeyIterateOr(varName string) PredExp {
+	return &predExpString{tag: _AS_PREDEXP_MAPKEY_ITERATE_OR, value: varName, name: "MAPKEY_ITERATE_OR using %s:"}
+}
+
+// NewPredExpMapKeyIterateAnd creates a predicate expression which is true if the
+// preceding expression is true for all keys of the preceding map bin.
+// The key is available to the expression through the named variable.
+func NewPredExpMapKeyIterateAnd(varName string) PredExp {
+	return &predExpString{tag: _AS_PREDEXP_MAPKEY_ITERATE_AND, value: varName, name: "MAPKEY_ITERATE_AND using %s:"}
+}
+
+// NewPredExpMapValIterateOr creates a predicate expression which is true if the
+// preceding expression is true for any value of the preceding map bin.
+// The value is available to the expression through the named variable.
+func NewPredExpMapValIterateOr(varName string) PredExp {
+	return &predExpString{tag: _AS_PREDEXP_MAPVAL_ITERATE_OR, value: varName, name: "MAPVAL_ITERATE_OR using %s:"}
+}
+
+// NewPredExpMapValIterateAnd creates a predicate expression which is true if the
+// preceding expression is true for all values of the preceding map bin.
+// The value is available to the expression through the named variable.
+func NewPredExpMapValIterateAnd(varName string) PredExp {
+	return &predExpString{tag: _AS_PREDEXP_MAPVAL_ITERATE_AND, value: varName, name: "MAPVAL_ITERATE_AND using %s:"}
+}
+
+func (e *predExpString) String() string {
+	return fmt.Sprintf(e.name, e.value)
+}
+
+func (e *predExpString) estimateSize() int {
+	return _PREDEXP_HEADER_SIZE + len(e.value)
+}
+
+func (e *predExpString) write(buf []byte, offset int) (int, error) {
+	offset = writePredExpHeader(buf, offset, e.tag, len(e.value))
+	return offset + copy(buf[offset:], e.value), nil
+}
+
+// NewPredExpRecDeviceSize creates a predicate expression for the
+// storage size of the record in bytes.
+func NewPredExpRecDeviceSize() PredExp {
+	return &predExpOp{tag: _AS_PREDEXP_REC_DEVICE_SIZE, name: "rec.DeviceSize"}
+}
+
+// NewPredExpRecLastUpdate creates a predicate expression for the last update
+// time of the record, in nanoseconds since the Unix epoch.
+func NewPredExpRecLastUpdate() PredExp {
+	return &predExpOp{tag: _AS_PREDEXP_REC_LAST_UPDATE, name: "rec.LastUpdate"}
+}
+
+// NewPredExpRecVoidTime creates a predicate expression for the expiration
+// time of the record, in nanoseconds since the Unix epoch.
+// The value is 0 for records which never expire.
+func NewPredExpRecVoidTime() PredExp {
+	return &predExpOp{tag: _AS_PREDEXP_REC_VOID_TIME, name: "rec.Expiration"}
+}
+
+// predExpDigestModulo represents the record digest modulo expression.
+type predExpDigestModulo struct {
+	mod int32
+}
+
+// NewPredExpRecDigestModulo creates a predicate expression for the record
+// digest modulo mod. It can be used to select a sample of the records.
+func NewPredExpRecDigestModulo(mod int32) PredExp {
+	return &predExpDigestModulo{mod: mod}
+}
+
+func (e *predExpDigestModulo) String() string {
+	return fmt.Sprintf("rec.DigestModulo(%d)", e.mod)
+}
+
+func (e *predExpDigestModulo) estimateSize() int {
+	return _PREDEXP_HEADER_SIZE + 4
+}
+
+func (e *predExpDigestModulo) write(buf []byte, offset int) (int, error) {
+	offset = writePredExpHeader(buf, offset, _AS_PREDEXP_REC_DIGEST_MODULO, 4)
+	Buffer.Int32ToBytes(e.mod, buf, offset)
+	return offset + 4, nil
+}
+
+// NewPredExpIntegerEqual creates an equality predicate expression
+// for the two preceding integer expressions.
+func NewPredExpIntegerEqual() PredExp {
+	return &predExpOp{tag: _AS_PREDEXP_INTEGER_EQUAL, name: "="}
+}
+
+// NewPredExpIntegerUnequal creates an inequality predicate expression
+// for the two preceding integer expressions.
+func NewPredExpIntegerUnequal() PredExp {
+	return &predExpOp{tag: _AS_PREDEXP_INTEGER_UNEQUAL, name: "!="}
+}
+
+// NewPredExpIntegerGreater creates a greater than predicate expression
+// for the two preceding integer expressions.
+func NewPredExpIntegerGreater() PredExp {
+	return &predExpOp{tag: _AS_PREDEXP_INTEGER_GREATER, name: ">"}
+}
+
+// NewPredExpIntegerGreaterEq creates a greater than or equal predicate expression
+// for the two preceding integer expressions.
+func NewPredExpIntegerGreaterEq() PredExp {
+	return &predExpOp{tag: _AS_PREDEXP_INTEGER_GREATEREQ, name: ">="}
+}
+
+// NewPredExpIntegerLess creates a less than predicate expression
+// for the two preceding integer expressions.
+func NewPredExpIntegerLess() PredExp {
+	return &predExpOp{tag: _AS_PREDEXP_INTEGER_LESS, name: "<"}
+}
+
+// NewPredExpIntegerLessEq creates a less than or equal predicate expression
+// for the two preceding integer expressions.
+func NewPredExpIntegerLessEq() PredExp {
+	return &predExpOp{tag: _AS_PREDEXP_INTEGER_LESSEQ, name: "<="}
+}
+
+// NewPredExpStringEqual creates an equality predicate expression
+// for the two preceding string expressions.
+func NewPredExpStringEqual() PredExp {
+	return &predExpOp{tag: _AS_PREDEXP_STRING_EQUAL, name: "="}
+}
+
+// NewPredExpStringUnequal creates an inequality predicate expression
+// for the two preceding string expressions.
+func NewPredExpStringUnequal() PredExp {
+	return &predExpOp{tag: _AS_PREDEXP_STRING_UNEQUAL, name: "!="}
+}
+
+// predExpStringRegex represents the regular expression match expression.
+type predExpStringRegex struct {
+	flags RegexFlags
+}
+
+// NewPredExpStringRegex creates a predicate expression which matches the
+// preceding string expression against the preceding regular expression
+// string value, e.g.:
+//
+//	NewPredExpStringBin("name"),
+//	NewPredExpStringValue("^jo.*"),
+//	NewPredExpStringRegex(REGEX_ICASE),
+func NewPredExpStringRegex(flags RegexFlags) PredExp {
+	return &predExpStringRegex{flags: flags}
+}
+
+func (e *predExpStringRegex) String() string {
+	return "~="
+}
+
+func (e *predExpStringRegex) estimateSize() int {
+	return _PREDEXP_HEADER_SIZE + 4
+}
+
+func (e *predExpStringRegex) write(buf []byte, offset int) (int, error) {
+	offset = writePredExpHeader(buf, offset, _AS_PREDEXP_STRING_REGEX, 4)
+	Buffer.Int32ToBytes(int32(e.flags), buf, offset)
+	return offset + 4, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func testPredExpBytes(predExp ...PredExp) []byte {
+	buf := make([]byte, estimatePredExpSize(predExp))
+
+	offset := 0
+	for _, pred := range predExp {
+		var err error
+		offset, err = pred.write(buf, offset)
+		Expect(err).ToNot(HaveOccurred())
+	}
+	Expect(offset).To(Equal(len(buf)))
+
+	return buf
+}
+
+var _ = Describe("Predicate Expression Test", func() {
+
+	It("should serialize AND, OR and NOT", func() {
+		Expect(testPredExpBytes(NewPredExpAnd(2))).To(Equal([]byte{0, 1, 0, 0, 0, 2, 0, 2}))
+		Expect(testPredExpBytes(NewPredExpOr(3))).To(Equal([]byte{0, 2, 0, 0, 0, 2, 0, 3}))
+		Expect(testPredExpBytes(NewPredExpNot())).To(Equal([]byte{0, 3, 0, 0, 0, 0}))
+	})
+
+	It("should serialize values", func() {
+		Expect(testPredExpBytes(NewPredExpIntegerValue(-2))).To(Equal([]byte{0, 10, 0, 0, 0, 8, 255, 255, 255, 255, 255, 255, 255, 254}))
+		Expect(testPredExpBytes(NewPredExpStringValue("ab"))).To(Equal([]byte{0, 11, 0, 0, 0, 2, 'a', 'b'}))
+	})
+
+	It("should serialize bins and variables", func() {
+		Expect(testPredExpBytes(NewPredExpIntegerBin("a"))).To(Equal([]byte{0, 100, 0, 0, 0, 1, 'a'}))
+		Expect(testPredExpBytes(NewPredExpStringBin("a"))).To(Equal([]byte{0, 101, 0, 0, 0, 1, 'a'}))
+		Expect(testPredExpBytes(NewPredExpListBin("a"))).To(Equal([]byte{0, 103, 0, 0, 0, 1, 'a'}))
+		Expect(testPredExpBytes(NewPredExpMapBin("a"))).To(Equal([]byte{0, 104, 0, 0, 0, 1, 'a'}))
+		Expect(testPredExpBytes(NewPredExpIntegerVar("x"))).To(Equal([]byte{0, 120, 0, 0, 0, 1, 'x'}))
+		Expect(testPredExpBytes(NewPredExpStringVar("x"))).To(Equal([]byte{0, 121, 0, 0, 0, 1, 'x'}))
+	})
+
+	It("should serialize record metadata", func() {
+		Expect(testPredExpBytes(NewPredExpRecDeviceSize())).To(Equal([]byte{0, 150, 0, 0, 0, 0}))
+		Expect(testPredExpBytes(NewPredExpRecLastUpdate())).To(Equal([]byte{0, 151, 0, 0, 0, 0}))
+		Expect(testPredExpBytes(NewPredExpRecVoidTime())).To(Equal([]byte{0, 152, 0, 0, 0, 0}))
+		Expect(testPredExpBytes(NewPredExpRecDigestModulo(3))).To(Equal([]byte{0, 153, 0, 0, 0, 4, 0, 0, 0, 3}))
+	})
+
+	It("should serialize comparisons", func() {
+		Expect(testPredExpBytes(NewPredExpIntegerEqual())).To(Equal([]byte{0, 200, 0, 0, 0, 0}))
+		Expect(testPredExpBytes(NewPredExpIntegerUnequal())).To(Equal([]byte{0, 201, 0, 0, 0, 0}))
+		Expect(testPredExpBytes(NewPredExpIntegerGreater())).To(Equal([]byte{0, 202, 0, 0, 0, 0}))
+		Expect(testPredExpBytes(NewPredExpIntegerGreaterEq())).To(Equal([]byte{0, 203, 0, 0, 0, 0}))
+		Expect(testPredExpBytes(NewPredExpIntegerLess())).To(Equal([]byte{0, 204, 0, 0, 0, 0}))
+		Expect(testPredExpBytes(NewPredExpIntegerLessEq())).To(Equal([]byte{0, 205, 0, 0, 0, 0}))
+		Expect(testPredExpBytes(NewPredExpStringEqual())).To(Equal([]byte{0, 210, 0, 0, 0, 0}))
+		Expect(testPredExpBytes(NewPredExpStringUnequal())).To(Equal([]byte{0, 211, 0, 0, 0, 0}))
+		Expect(testPredExpBytes(NewPredExpStringRegex(REGEX_EXTENDED | REGEX_ICASE))).To(Equal([]byte{0, 212, 0, 0, 0, 4, 0, 0, 0, 3}))
+	})
+
+	It("should serialize iterations", func() {
+		Expect(testPredExpBytes(NewPredExpListIterateOr("x"))).To(Equal([]byte{0, 250, 0, 0, 0, 1, 'x'}))
+		Expect(testPredExpBytes(NewPredExpMapKeyIterateOr("x"))).To(Equal([]byte{0, 251, 0, 0, 0, 1, 'x'}))
+		Expect(testPredExpBytes(NewPredExpMapValIterateOr("x"))).To(Equal([]byte{0, 252, 0, 0, 0, 1, 'x'}))
+		Expect(testPredExpBytes(NewPredExpListIterateAnd("x"))).To(Equal([]byte{0, 253, 0, 0, 0, 1, 'x'}))
+		Expect(testPredExpBytes(NewPredExpMapKeyIterateAnd("x"))).To(Equal([]byte{0, 254, 0, 0, 0, 1, 'x'}))
+		Expect(testPredExpBytes(NewPredExpMapValIterateAnd("x"))).To(Equal([]byte{0, 255, 0, 0, 0, 1, 'x'}))
+	})
+
+	It("should serialize compound expressions in order", func() {
+		predExp := []PredExp{
+			NewPredExpStringBin("a"),
+			NewPredExpStringValue("b"),
+			NewPredExpStringEqual(),
+			NewPredExpNot(),
+		}
+
+		Expect(testPredExpBytes(predExp...)).To(Equal([]byte{
+			0, 101, 0, 0, 0, 1, 'a',
+			0, 11, 0, 0, 0, 1, 'b',
+			0, 210, 0, 0, 0, 0,
+			0, 3, 0, 0, 0, 0,
+		}))
+	})
+
+	It("should print expressions in postfix notation", func() {
+		Expect(NewPredExpIntegerBin("a").String()).To(Equal("$a"))
+		Expect(NewPredExpStringValue("b").String()).To(Equal("'b'"))
+		Expect(NewPredExpAnd(2).String()).To(Equal("AND(2)"))
+		Expect(NewPredExpIntegerGreaterEq().String()).To(Equal(">="))
+	})
+
+})
@@ -0,0 +1,98 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	. "github.com/aerospike/aerospike-client-go/types"
+)
+
+// Privilege determines the operations a role may perform.
+// The data privileges (Read, ReadWrite and ReadWriteUDF) can be scoped
+// to a namespace, or to a set in a namespace. The administrative
+// privileges (UserAdmin, SysAdmin and DataAdmin) are global.
+type Privilege struct {
+	// Code is the role whose operations are permitted.
+	Code Role
+
+	// Namespace limits the privilege to the namespace.
+	// If empty, the privilege applies to all namespaces.
+	Namespace string
+
+	// SetName limits the privilege to the set of the namespace.
+	// If empty, the privilege applies to all sets of the namespace.
+	SetName string
+}
+
+// RolePrivileges contains the privileges of a role.
+type RolePrivileges struct {
+	// Role name.
+	Role string
+
+	// List of privileges of the role.
+	Privileges []Privilege
+}
+
+// Privilege codes as sent on the wire.
+const (
+	_PRIV_USER_ADMIN     byte = 0
+	_PRIV_SYS_ADMIN      byte = 1
+	_PRIV_DATA_ADMIN     byte = 2
+	_PRIV_READ           byte = 10
+	_PRIV_READ_WRITE     byte = 11
+	_PRIV_READ_WRITE_UDF byte = 12
+)
+
+func (p *Privilege) code() (byte, error) {
+	switch p.Code {
+	case UserAdmin:
+		return _PRIV_USER_ADMIN, nil
+	case SysAdmin:
+		return _PRIV_SYS_ADMIN, nil
+	case DataAdmin:
+		return _PRIV_DATA_ADMIN, nil
+	case Read:
+		return _PRIV_READ, nil
+	case ReadWrite:
+		return _PRIV_READ_WRITE, nil
+	case ReadWriteUDF:
+		return _PRIV_READ_WRITE_UDF, nil
+	}
+	return 0, NewAerospikeError(INVALID_PRIVILEGE, "Invalid privilege code: "+string(p.Code))
+}
+
+// privilegeRole returns the role of the privilege code.
+func privilegeRole(code byte) Role {
+	switch code {
+	case _PRIV_USER_ADMIN:
+		return UserAdmin
+	case _PRIV_SYS_ADMIN:
+		return SysAdmin
+	case _PRIV_DATA_ADMIN:
+		return DataAdmin
+	case _PRIV_READ:
+		return Read
+	case _PRIV_READ_WRITE:
+		return ReadWrite
+	case _PRIV_READ_WRITE_UDF:
+		return ReadWriteUDF
+	}
+	return ""
+}
+
+// canScope returns true if the privilege code can be limited
+// to a namespace or set.
+func canScope(code byte) bool {
+	return code >= _PRIV_READ
+}
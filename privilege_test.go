@@ -0,0 +1,55 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	. "github.com/aerospike/aerospike-client-go/types"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Privilege Test", func() {
+
+	It("should write and parse the privileges", func() {
+		privileges := []Privilege{
+			{Code: UserAdmin},
+			{Code: Read, Namespace: "test"},
+			{Code: ReadWriteUDF, Namespace: "test", SetName: "demo"},
+		}
+
+		acmd := newAdminCommand()
+		acmd.dataOffset = 0
+		Expect(acmd.writePrivileges(privileges)).ToNot(HaveOccurred())
+
+		// skip the field header
+		acmd.dataOffset = int(_FIELD_HEADER_SIZE)
+		rolePrivileges := &RolePrivileges{}
+		acmd.parsePrivileges(rolePrivileges)
+		Expect(rolePrivileges.Privileges).To(Equal(privileges))
+	})
+
+	It("should reject invalid privilege scopes", func() {
+		err := newAdminCommand().writePrivileges([]Privilege{{Code: SysAdmin, Namespace: "test"}})
+		Expect(err.(AerospikeError).ResultCode()).To(Equal(INVALID_PRIVILEGE))
+
+		err = newAdminCommand().writePrivileges([]Privilege{{Code: Read, SetName: "demo"}})
+		Expect(err.(AerospikeError).ResultCode()).To(Equal(INVALID_PRIVILEGE))
+
+		err = newAdminCommand().writePrivileges([]Privilege{{Code: Role("unknown")}})
+		Expect(err.(AerospikeError).ResultCode()).To(Equal(INVALID_PRIVILEGE))
+	})
+
+})
@@ -0,0 +1,134 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"fmt"
+
+	. "github.com/aerospike/aerospike-client-go/types"
+	Buffer "github.com/aerospike/aerospike-client-go/utils/buffer"
+)
+
+type queryAggregateCommand struct {
+	*queryCommand
+
+	inputChan chan interface{}
+}
+
+func newQueryAggregateCommand(node *Node, policy *QueryPolicy, statement *Statement, recordset *Recordset, inputChan chan interface{}) *queryAggregateCommand {
+	return &queryAggregateCommand{
+		queryCommand: newQueryCommand(node, policy, statement, recordset),
+		inputChan:    inputChan,
+	}
+}
+
+func (cmd *queryAggregateCommand) parseRecordResults(ifc command, receiveSize int) (bool, error) {
+	// Read/parse remaining message bytes one record at a time.
+	cmd.dataOffset = 0
+
+	for cmd.dataOffset < receiveSize {
+		if err := cmd.readBytes(int(_MSG_REMAINING_HEADER_SIZE)); err != nil {
+			cmd.recordset.Errors <- newNodeError(cmd.node, err)
+			return false, err
+		}
+		resultCode := ResultCode(cmd.dataBuffer[5] & 0xFF)
+
+		if resultCode != 0 {
+			if resultCode == KEY_NOT_FOUND_ERROR {
+				return false, nil
+			}
+			err := NewAerospikeError(resultCode)
+			cmd.recordset.Errors <- newNodeError(cmd.node, err)
+			return false, err
+		}
+
+		info3 := int(cmd.dataBuffer[3])
+
+		// If cmd is the end marker of the response, do not proceed further
+		if (info3 & _INFO3_LAST) == _INFO3_LAST {
+			return false, nil
+		}
+
+		fieldCount := int(uint16(Buffer.BytesToInt16(cmd.dataBuffer, 18)))
+		opCount := int(uint16(Buffer.BytesToInt16(cmd.dataBuffer, 20)))
+
+		if opCount != 1 {
+			err := NewAerospikeError(PARSE_ERROR, fmt.Sprintf("Query aggregate expected exactly one bin. Received %d.", opCount))
+			cmd.recordset.Errors <- newNodeError(cmd.node, err)
+			return false, err
+		}
+
+		if _, err := cmd.parseKey(fieldCount); err != nil {
+			cmd.recordset.Errors <- newNodeError(cmd.node, err)
+			return false, err
+		}
+
+		// Parse aggregateValue.
+		if err := cmd.readBytes(8); err != nil {
+			cmd.recordset.Errors <- newNodeError(cmd.node, err)
+			return false, err
+		}
+
+		opSize := int(uint32(Buffer.BytesToInt32(cmd.dataBuffer, 0)))
+		particleType := int(cmd.dataBuffer[5])
+		nameSize := int(cmd.dataBuffer[7])
+
+		if err := cmd.readBytes(nameSize); err != nil {
+			cmd.recordset.Errors <- newNodeError(cmd.node, err)
+			return false, err
+		}
+		name := string(cmd.dataBuffer[:nameSize])
+
+		particleBytesSize := int((opSize - (4 + nameSize)))
+		if err := cmd.readBytes(particleBytesSize); err != nil {
+			cmd.recordset.Errors <- newNodeError(cmd.node, err)
+			return false, err
+		}
+		value, err := bytesToParticle(particleType, cmd.dataBuffer, 0, particleBytesSize)
+		if err != nil {
+			cmd.recordset.Errors <- newNodeError(cmd.node, err)
+			return false, err
+		}
+
+		switch name {
+		case "SUCCESS":
+		case "FAILURE":
+			err := NewAerospikeError(QUERY_GENERIC, fmt.Sprintf("%v", value))
+			cmd.recordset.Errors <- newNodeError(cmd.node, err)
+			return false, err
+		default:
+			err := NewAerospikeError(PARSE_ERROR, fmt.Sprintf("Query aggregate expected bin name SUCCESS. Received %s.", name))
+			cmd.recordset.Errors <- newNodeError(cmd.node, err)
+			return false, err
+		}
+
+		// If the channel is full and it blocks, we don't want this command to
+		// block forever, or panic in case the channel is closed in the meantime.
+		select {
+		// send the result to the client-side aggregation
+		case cmd.inputChan <- value:
+		case <-cmd.recordset.cancelled:
+			return false, NewAerospikeError(QUERY_TERMINATED)
+		}
+	}
+
+	return true, nil
+}
+
+// Execute does not signal the end of the recordset; the client-side
+// aggregation does, after all node results have been processed.
+func (cmd *queryAggregateCommand) Execute() error {
+	return cmd.execute(cmd)
+}
@@ -21,6 +21,10 @@ type queryCommand struct {
 
 	policy    *QueryPolicy
 	statement *Statement
+
+	// write policy and operations for background queries
+	writePolicy *WritePolicy
+	operations  []*Operation
 }
 
 func newQueryCommand(node *Node, policy *QueryPolicy, statement *Statement, recordset *Recordset) *queryCommand {
@@ -41,6 +45,7 @@ func (cmd *queryCommand) writeBuffer(ifc command) (err error) {
 	fieldCount := 0
 	filterSize := 0
 	binNameSize := 0
+	predSize := 0
 
 	cmd.begin()
 
@@ -79,6 +84,12 @@ func (cmd *queryCommand) writeBuffer(ifc command) (err error) {
 		fieldCount++
 	}
 
+	if len(cmd.statement.PredExp) > 0 {
+		predSize = estimatePredExpSize(cmd.statement.PredExp)
+		cmd.dataOffset += predSize + int(_FIELD_HEADER_SIZE)
+		fieldCount++
+	}
+
 	if len(cmd.statement.BinNames) > 0 {
 		cmd.dataOffset += int(_FIELD_HEADER_SIZE)
 		binNameSize++ // num bin names
@@ -112,12 +123,21 @@ func (cmd *queryCommand) writeBuffer(ifc command) (err error) {
 		cmd.dataOffset += int(_FIELD_HEADER_SIZE) + len(functionArgBuffer)
 		fieldCount += 4
 	}
+
+	for _, operation := range cmd.operations {
+		cmd.estimateOperationSizeForOperation(operation)
+	}
+
 	if err := cmd.sizeBuffer(); err != nil {
 		return nil
 	}
 
-	readAttr := _INFO1_READ
-	cmd.writeHeader(cmd.policy.GetBasePolicy(), readAttr, 0, fieldCount, 0)
+	if len(cmd.operations) > 0 {
+		cmd.writeHeaderWithPolicy(cmd.writePolicy, 0, _INFO2_WRITE, fieldCount, len(cmd.operations))
+	} else {
+		readAttr := _INFO1_READ
+		cmd.writeHeader(cmd.policy.GetBasePolicy(), readAttr, 0, fieldCount, 0)
+	}
 
 	if cmd.statement.Namespace != "" {
 		cmd.writeFieldString(cmd.statement.Namespace, NAMESPACE)
@@ -153,6 +173,12 @@ func (cmd *queryCommand) writeBuffer(ifc command) (err error) {
 		cmd.dataOffset++
 	}
 
+	if len(cmd.statement.PredExp) > 0 {
+		if err := cmd.writeFieldPredExp(cmd.statement.PredExp, predSize); err != nil {
+			return err
+		}
+	}
+
 	if len(cmd.statement.BinNames) > 0 {
 		cmd.writeFieldHeader(binNameSize, QUERY_BINLIST)
 		cmd.dataBuffer[cmd.dataOffset] = byte(len(cmd.statement.BinNames))
@@ -183,6 +209,12 @@ func (cmd *queryCommand) writeBuffer(ifc command) (err error) {
 		cmd.writeFieldString(cmd.statement.functionName, UDF_FUNCTION)
 		cmd.writeFieldBytes(functionArgBuffer, UDF_ARGLIST)
 	}
+
+	for _, operation := range cmd.operations {
+		if err := cmd.writeOperationForOperation(operation); err != nil {
+			return err
+		}
+	}
 	cmd.end()
 
 	return nil
@@ -35,6 +35,7 @@ func newQueryRecordCommand(node *Node, policy *QueryPolicy, statement *Statement
 func (cmd *queryRecordCommand) parseRecordResults(ifc command, receiveSize int) (bool, error) {
 	// Read/parse remaining message bytes one record at a time.
 	cmd.dataOffset = 0
+	policy := cmd.policy.GetBasePolicy()
 
 	for cmd.dataOffset < receiveSize {
 		if err := cmd.readBytes(int(_MSG_REMAINING_HEADER_SIZE)); err != nil {
@@ -72,6 +73,7 @@ func (cmd *queryRecordCommand) parseRecordResults(ifc command, receiveSize int)
 
 		// Parse bins.
 		var bins BinMap
+		var raw map[string]rawBin
 
 		for i := 0; i < opCount; i++ {
 			if err := cmd.readBytes(8); err != nil {
@@ -94,7 +96,16 @@ func (cmd *queryRecordCommand) parseRecordResults(ifc command, receiveSize int)
 				cmd.recordset.Errors <- newNodeError(cmd.node, err)
 				return false, err
 			}
-			value, err := bytesToParticle(particleType, cmd.dataBuffer, 0, particleBytesSize)
+
+			if policy.LazyBins && isLazyParticle(particleType) {
+				if raw == nil {
+					raw = make(map[string]rawBin, opCount)
+				}
+				raw[name] = newRawBin(particleType, cmd.dataBuffer, 0, particleBytesSize)
+				continue
+			}
+
+			value, err := decodeParticle(particleType, cmd.dataBuffer, 0, particleBytesSize, policy.OrderedMaps)
 			if err != nil {
 				cmd.recordset.Errors <- newNodeError(cmd.node, err)
 				return false, err
@@ -110,7 +121,7 @@ func (cmd *queryRecordCommand) parseRecordResults(ifc command, receiveSize int)
 		// block forever, or panic in case the channel is closed in the meantime.
 		select {
 		// send back the result on the async channel
-		case cmd.recordset.Records <- newRecord(cmd.node, key, bins, generation, expiration):
+		case cmd.recordset.Records <- newRecord(cmd.node, key, bins, generation, expiration).setRawBins(raw, policy.OrderedMaps):
 		case <-cmd.recordset.cancelled:
 			return false, NewAerospikeError(SCAN_TERMINATED)
 		}
@@ -15,8 +15,12 @@
 package aerospike_test
 
 import (
+	"io/ioutil"
 	"math"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
 
 	. "github.com/aerospike/aerospike-client-go"
 
@@ -39,6 +43,18 @@ function filter_by_name(stream,name)
  return stream : filter(filter_name) : map(map_profile)
 end`
 
+const udfSum = `
+local function add(a, b)
+ return a + b
+end
+
+function sum_bin(stream, name)
+ local function bin_value(record)
+   return record[name]
+ end
+ return stream : map(bin_value) : reduce(add)
+end`
+
 // ALL tests are isolated by SetName and Key, which are 50 random charachters
 var _ = Describe("Query operations", func() {
 	initTestVars()
@@ -189,6 +205,83 @@ var _ = Describe("Query operations", func() {
 		Expect(cnt).To(BeNumerically(">", 0))
 	})
 
+	It("must Query a range and aggregate the results on the client", func() {
+		regTask, err := client.RegisterUDF(nil, []byte(udfSum), "udfSum.lua", LUA)
+		Expect(err).ToNot(HaveOccurred())
+
+		// wait until UDF is created
+		err = <-regTask.OnComplete()
+		Expect(err).ToNot(HaveOccurred())
+
+		// the client-side stage of the aggregation needs the module locally
+		luaPath, err := ioutil.TempDir("", "udf")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(luaPath)
+
+		err = ioutil.WriteFile(filepath.Join(luaPath, "udfSum.lua"), []byte(udfSum), 0644)
+		Expect(err).ToNot(HaveOccurred())
+		SetLuaPath(luaPath)
+
+		stm := NewStatement(ns, set)
+		stm.Addfilter(NewRangeFilter(bin3.Name, 0, math.MaxInt16))
+
+		recordset, err := client.QueryAggregate(nil, stm, "udfSum", "sum_bin", NewValue(bin5.Name))
+		Expect(err).ToNot(HaveOccurred())
+
+		cnt := 0
+		for res := range recordset.Results() {
+			Expect(res.Err).ToNot(HaveOccurred())
+			Expect(res.Record.Bins["SUCCESS"]).To(Equal(-keyCount))
+			cnt++
+		}
+
+		Expect(cnt).To(Equal(1))
+	})
+
+	It("must Query a specific range and filter the records on the server using predicate expressions", func() {
+		stm := NewStatement(ns, set)
+		stm.Addfilter(NewRangeFilter(bin3.Name, 0, math.MaxInt16))
+		stm.SetPredExp(
+			NewPredExpIntegerBin(bin3.Name),
+			NewPredExpIntegerValue(math.MaxInt16/2),
+			NewPredExpIntegerLessEq(),
+			NewPredExpStringBin(bin4.Name),
+			NewPredExpStringValue("^CONST"),
+			NewPredExpStringRegex(REGEX_ICASE),
+			NewPredExpAnd(2),
+		)
+
+		recordset, err := client.Query(nil, stm)
+		Expect(err).ToNot(HaveOccurred())
+
+		cnt := 0
+		for res := range recordset.Results() {
+			Expect(res.Err).ToNot(HaveOccurred())
+			Expect(res.Record.Bins[bin3.Name]).To(BeNumerically("<=", math.MaxInt16/2))
+			Expect(res.Record.Bins[bin4.Name]).To(Equal("constValue"))
+			cnt++
+		}
+
+		Expect(cnt).To(BeNumerically(">", 0))
+	})
+
+	It("must Query records by their last update time using predicate expressions", func() {
+		stm := NewStatement(ns, set)
+		stm.SetPredExp(
+			NewPredExpRecLastUpdate(),
+			NewPredExpIntegerValue(time.Now().Add(time.Hour).UnixNano()),
+			NewPredExpIntegerGreater(),
+		)
+
+		recordset, err := client.Query(nil, stm)
+		Expect(err).ToNot(HaveOccurred())
+
+		for res := range recordset.Results() {
+			Expect(res.Err).ToNot(HaveOccurred())
+			Fail("no records should have been updated in the future")
+		}
+	})
+
 	It("must Query specific equality filters and get only relevant records back", func() {
 		// save a record with requested value
 		key, err := NewKey(ns, set, randString(50))
@@ -0,0 +1,125 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"unsafe"
+
+	. "github.com/aerospike/aerospike-client-go/logger"
+
+	. "github.com/aerospike/aerospike-client-go/types"
+	Buffer "github.com/aerospike/aerospike-client-go/utils/buffer"
+)
+
+// BinHandler is called by GetWithHandler for each bin of the record, with the bin's
+// particle type and its value in the wire protocol, e.g. a big-endian integer for
+// ParticleType.INTEGER, or MessagePack for lists and maps.
+// binName and raw point into the command buffer and are only valid during the call;
+// copy them to retain them.
+// Returning an error aborts the command, and the error is returned to the caller.
+type BinHandler func(binName string, particleType int, raw []byte) error
+
+type readHandlerCommand struct {
+	*readCommand
+
+	handler BinHandler
+	found   bool
+}
+
+func newReadHandlerCommand(cluster *Cluster, policy Policy, key *Key, binNames []string, handler BinHandler) *readHandlerCommand {
+	return &readHandlerCommand{
+		readCommand: newReadCommand(cluster, policy, key, binNames),
+		handler:     handler,
+	}
+}
+
+func (cmd *readHandlerCommand) parseResult(ifc command, conn *Connection) error {
+	// Read header.
+	_, err := conn.Read(cmd.dataBuffer, int(_MSG_TOTAL_HEADER_SIZE))
+	if err != nil {
+		Logger.Warn("parse result error: " + err.Error())
+		return err
+	}
+
+	sz := Buffer.BytesToInt64(cmd.dataBuffer, 0)
+	headerLength := int(cmd.dataBuffer[8])
+	resultCode := ResultCode(cmd.dataBuffer[13] & 0xFF)
+	fieldCount := int(uint16(Buffer.BytesToInt16(cmd.dataBuffer, 26)))
+	opCount := int(uint16(Buffer.BytesToInt16(cmd.dataBuffer, 28)))
+	receiveSize := int((sz & 0xFFFFFFFFFFFF) - int64(headerLength))
+
+	// Read remaining message bytes.
+	if receiveSize > 0 {
+		if err = cmd.sizeBufferSz(receiveSize); err != nil {
+			return err
+		}
+		_, err = conn.Read(cmd.dataBuffer, receiveSize)
+		if err != nil {
+			Logger.Warn("parse result error: " + err.Error())
+			return err
+		}
+	}
+
+	if resultCode != 0 {
+		if resultCode == KEY_NOT_FOUND_ERROR {
+			return nil
+		}
+		return NewAerospikeError(resultCode)
+	}
+
+	cmd.found = true
+	return parseRawBins(cmd.dataBuffer, skipFields(cmd.dataBuffer, 0, fieldCount), opCount, cmd.handler)
+}
+
+func (cmd *readHandlerCommand) Execute() error {
+	return cmd.execute(cmd)
+}
+
+// skipFields returns the offset of the operations following the fields.
+func skipFields(buf []byte, offset int, fieldCount int) int {
+	for i := 0; i < fieldCount; i++ {
+		fieldSize := int(uint32(Buffer.BytesToInt32(buf, offset)))
+		offset += 4 + fieldSize
+	}
+	return offset
+}
+
+// parseRawBins calls the handler for each operation in the buffer, without copying
+// the bin names and values out of it.
+func parseRawBins(buf []byte, offset int, opCount int, handler BinHandler) error {
+	for i := 0; i < opCount; i++ {
+		opSize := int(uint32(Buffer.BytesToInt32(buf, offset)))
+		particleType := int(buf[offset+5])
+		nameSize := int(buf[offset+7])
+		name := bytesToStringNoCopy(buf[offset+8 : offset+8+nameSize])
+		offset += 4 + 4 + nameSize
+
+		particleBytesSize := int(opSize - (4 + nameSize))
+		if err := handler(name, particleType, buf[offset:offset+particleBytesSize]); err != nil {
+			return err
+		}
+		offset += particleBytesSize
+	}
+	return nil
+}
+
+// bytesToStringNoCopy returns a string which shares its bytes with b.
+// The string is only valid as long as b is not modified.
+func bytesToStringNoCopy(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	return *(*string)(unsafe.Pointer(&b))
+}
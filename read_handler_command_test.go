@@ -0,0 +1,71 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"errors"
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Read Handler Test", func() {
+
+	It("should call the handler with the raw bins in the buffer", func() {
+		buf := rawBins(benchReadBins...)
+
+		names := []string{}
+		err := parseRawBins(buf, 0, len(benchReadBins), func(binName string, particleType int, raw []byte) error {
+			bin := benchReadBins[len(names)]
+			names = append(names, string([]byte(binName)))
+
+			Expect(particleType).To(Equal(bin.Value.GetType()))
+			value, err := bytesToParticle(particleType, raw, 0, len(raw))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal(bin.Value.GetObject()))
+			return nil
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(names).To(Equal([]string{"price", "name", "blob", "tags"}))
+	})
+
+	It("should skip the fields, and stop at the first error of the handler", func() {
+		// a 3 byte field
+		buf := append([]byte{0, 0, 0, 3, 4, 'n', 's'}, rawBins(benchReadBins...)...)
+		offset := skipFields(buf, 0, 1)
+		Expect(offset).To(Equal(7))
+
+		errStop := errors.New("stop")
+		calls := 0
+		err := parseRawBins(buf, offset, len(benchReadBins), func(binName string, particleType int, raw []byte) error {
+			calls++
+			return errStop
+		})
+		Expect(err).To(Equal(errStop))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("should not allocate for the bins", func() {
+		buf := rawBins(benchReadBins...)
+		handler := func(binName string, particleType int, raw []byte) error { return nil }
+
+		allocs := testing.AllocsPerRun(100, func() {
+			parseRawBins(buf, 0, len(benchReadBins), handler)
+		})
+		Expect(allocs).To(BeZero())
+	})
+
+})
@@ -16,6 +16,8 @@ package aerospike
 
 import (
 	"fmt"
+
+	ParticleType "github.com/aerospike/aerospike-client-go/types/particle_type"
 )
 
 // Record is the container struct for database records.
@@ -37,6 +39,30 @@ type Record struct {
 	// Expiration is TTL (Time-To-Live).
 	// Number of seconds until record expires.
 	Expiration int
+
+	// raw holds the list and map bins of a lazily decoded record until
+	// they are accessed.
+	raw         map[string]rawBin
+	orderedMaps bool
+}
+
+// rawBin is the undecoded value of a bin.
+type rawBin struct {
+	particleType int
+	buf          []byte
+}
+
+// isLazyParticle returns true if bins of the particle type are not decoded
+// until accessed when the policy's LazyBins is set.
+func isLazyParticle(particleType int) bool {
+	return particleType == ParticleType.LIST || particleType == ParticleType.MAP
+}
+
+// newRawBin copies the particle, since the buffer it was read from is reused.
+func newRawBin(particleType int, buf []byte, offset int, length int) rawBin {
+	raw := make([]byte, length)
+	copy(raw, buf[offset:offset+length])
+	return rawBin{particleType: particleType, buf: raw}
 }
 
 func newRecord(node *Node, key *Key, bins BinMap, generation int, expiration int) *Record {
@@ -56,6 +82,42 @@ func newRecord(node *Node, key *Key, bins BinMap, generation int, expiration int
 	return r
 }
 
+// setRawBins sets the undecoded bins of a lazily decoded record.
+func (rc *Record) setRawBins(raw map[string]rawBin, orderedMaps bool) *Record {
+	rc.raw = raw
+	rc.orderedMaps = orderedMaps
+	return rc
+}
+
+// Bin returns the value of the named bin, or nil if the record has no such bin.
+// If the record was read with the policy's LazyBins set, list and map bins are
+// not in Bins until they are decoded by Bin or DecodeBins.
+// Records with undecoded bins are not safe for concurrent use.
+func (rc *Record) Bin(name string) (interface{}, error) {
+	rb, exists := rc.raw[name]
+	if !exists {
+		return rc.Bins[name], nil
+	}
+
+	value, err := decodeParticle(rb.particleType, rb.buf, 0, len(rb.buf), rc.orderedMaps)
+	if err != nil {
+		return nil, err
+	}
+	rc.Bins[name] = value
+	delete(rc.raw, name)
+	return value, nil
+}
+
+// DecodeBins decodes all the remaining bins of a lazily decoded record into Bins.
+func (rc *Record) DecodeBins() error {
+	for name := range rc.raw {
+		if _, err := rc.Bin(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // String implements the Stringer interface.
 // Returns string representation of record.
 func (rc *Record) String() string {
@@ -0,0 +1,87 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	ParticleType "github.com/aerospike/aerospike-client-go/types/particle_type"
+)
+
+var _ = Describe("Record Test", func() {
+
+	parseRecord := func(policy *BasePolicy, bins ...*Bin) *Record {
+		cmd := &readCommand{
+			singleCommand: &singleCommand{baseCommand: &baseCommand{dataBuffer: rawBins(bins...)}},
+			policy:        policy,
+		}
+		rec, err := cmd.parseRecord(len(bins), 0, 0, 0)
+		Expect(err).ToNot(HaveOccurred())
+		return rec
+	}
+
+	It("should decode the list and map bins of a lazily decoded record on first access", func() {
+		policy := NewPolicy()
+		policy.LazyBins = true
+
+		mapBin := NewBin("map", map[interface{}]interface{}{"a": 1})
+		rec := parseRecord(policy, append(benchReadBins, mapBin)...)
+
+		Expect(rec.Bins).To(HaveLen(3))
+		Expect(rec.Bins).ToNot(HaveKey("tags"))
+		Expect(rec.Bins).ToNot(HaveKey("map"))
+
+		value, err := rec.Bin("tags")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal([]interface{}{"a", "b", 1}))
+		Expect(rec.Bins["tags"]).To(Equal(value))
+
+		value, err = rec.Bin("price")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(198))
+
+		value, err = rec.Bin("none")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(BeNil())
+
+		Expect(rec.DecodeBins()).To(Succeed())
+		Expect(rec.Bins).To(Equal(parseRecord(NewPolicy(), append(benchReadBins, mapBin)...).Bins))
+	})
+
+	It("should honor OrderedMaps for the lazily decoded bins", func() {
+		policy := NewPolicy()
+		policy.LazyBins = true
+		policy.OrderedMaps = true
+
+		pairs := []MapPair{{"b", 2}, {"a", 1}}
+		rec := parseRecord(policy, NewBin("map", pairs))
+		Expect(rec.Bins).To(BeEmpty())
+
+		value, err := rec.Bin("map")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(value).To(Equal(pairs))
+	})
+
+	It("should return the decoding errors", func() {
+		rec := newRecord(nil, nil, nil, 0, 0).setRawBins(map[string]rawBin{
+			"list": {particleType: ParticleType.LIST, buf: []byte{0x91, 0xc1}},
+		}, false)
+		_, err := rec.Bin("list")
+		Expect(err).To(HaveOccurred())
+		Expect(rec.DecodeBins()).ToNot(Succeed())
+	})
+
+})
@@ -41,6 +41,8 @@ type Recordset struct {
 
 	active    *AtomicBool
 	cancelled chan struct{}
+
+	taskId int64
 }
 
 // NewRecordset generates a new RecordSet instance.
@@ -57,6 +59,12 @@ func newRecordset(recSize, goroutines int) *Recordset {
 	return rs
 }
 
+// TaskId returns the id of the scan or query job on the server.
+// It can be used to monitor or abort the job.
+func (rcs *Recordset) TaskId() int64 {
+	return rcs.taskId
+}
+
 // IsActive returns true if the operation hasn't been finished or cancelled.
 func (rcs *Recordset) IsActive() bool {
 	return rcs.active.Get()
@@ -25,9 +25,15 @@ const (
 	// Manage indicies, user defined functions and server configuration.
 	SysAdmin Role = "sys-admin"
 
+	// Manage indicies and user defined functions.
+	DataAdmin Role = "data-admin"
+
 	// Allow read and write transactions with the database.
 	ReadWrite Role = "read-write"
 
+	// Allow read, write and user defined function transactions with the database.
+	ReadWriteUDF Role = "read-write-udf"
+
 	// Allow read transactions with the database.
-	Read Role = "Read"
+	Read Role = "read"
 )
@@ -26,6 +26,7 @@ type scanCommand struct {
 	namespace string
 	setName   string
 	binNames  []string
+	taskId    int64
 }
 
 func newScanCommand(
@@ -35,6 +36,7 @@ func newScanCommand(
 	setName string,
 	binNames []string,
 	recordset *Recordset,
+	taskId int64,
 ) *scanCommand {
 	return &scanCommand{
 		baseMultiCommand: newMultiCommand(node, recordset),
@@ -42,6 +44,7 @@ func newScanCommand(
 		namespace:        namespace,
 		setName:          setName,
 		binNames:         binNames,
+		taskId:           taskId,
 	}
 }
 
@@ -50,12 +53,13 @@ func (cmd *scanCommand) getPolicy(ifc command) Policy {
 }
 
 func (cmd *scanCommand) writeBuffer(ifc command) error {
-	return cmd.setScan(cmd.policy, &cmd.namespace, &cmd.setName, cmd.binNames)
+	return cmd.setScan(cmd.policy, &cmd.namespace, &cmd.setName, cmd.binNames, cmd.taskId)
 }
 
 func (cmd *scanCommand) parseRecordResults(ifc command, receiveSize int) (bool, error) {
 	// Read/parse remaining message bytes one record at a time.
 	cmd.dataOffset = 0
+	policy := cmd.policy.GetBasePolicy()
 
 	for cmd.dataOffset < receiveSize {
 		if err := cmd.readBytes(int(_MSG_REMAINING_HEADER_SIZE)); err != nil {
@@ -93,6 +97,7 @@ func (cmd *scanCommand) parseRecordResults(ifc command, receiveSize int) (bool,
 
 		// Parse bins.
 		var bins BinMap
+		var raw map[string]rawBin
 
 		for i := 0; i < opCount; i++ {
 			if err := cmd.readBytes(8); err != nil {
@@ -116,7 +121,15 @@ func (cmd *scanCommand) parseRecordResults(ifc command, receiveSize int) (bool,
 				return false, err
 			}
 
-			value, err := bytesToParticle(particleType, cmd.dataBuffer, 0, particleBytesSize)
+			if policy.LazyBins && isLazyParticle(particleType) {
+				if raw == nil {
+					raw = make(map[string]rawBin, opCount)
+				}
+				raw[name] = newRawBin(particleType, cmd.dataBuffer, 0, particleBytesSize)
+				continue
+			}
+
+			value, err := decodeParticle(particleType, cmd.dataBuffer, 0, particleBytesSize, policy.OrderedMaps)
 			if err != nil {
 				cmd.recordset.Errors <- newNodeError(cmd.node, err)
 				return false, err
@@ -132,7 +145,7 @@ func (cmd *scanCommand) parseRecordResults(ifc command, receiveSize int) (bool,
 		// block forever, or panic in case the channel is closed in the meantime.
 		select {
 		// send back the result on the async channel
-		case cmd.recordset.Records <- newRecord(cmd.node, key, bins, generation, expiration):
+		case cmd.recordset.Records <- newRecord(cmd.node, key, bins, generation, expiration).setRawBins(raw, policy.OrderedMaps):
 		case <-cmd.recordset.cancelled:
 			return false, NewAerospikeError(SCAN_TERMINATED)
 		}
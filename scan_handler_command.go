@@ -0,0 +1,155 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	. "github.com/aerospike/aerospike-client-go/types"
+	Buffer "github.com/aerospike/aerospike-client-go/utils/buffer"
+)
+
+// ScanHandler is called by ScanAllWithHandler for each bin of the scanned records,
+// with the digest of the record, the bin's particle type and its value in the wire
+// protocol, like BinHandler.
+// digest, binName and raw point into the command buffers and are only valid during
+// the call; copy them to retain them.
+// Returning an error aborts the scan, and the error is returned to the caller.
+type ScanHandler func(digest []byte, binName string, particleType int, raw []byte) error
+
+type scanHandlerCommand struct {
+	*baseMultiCommand
+
+	policy    *ScanPolicy
+	namespace string
+	setName   string
+	binNames  []string
+	taskId    int64
+
+	handler ScanHandler
+	digest  []byte
+}
+
+func newScanHandlerCommand(
+	node *Node,
+	policy *ScanPolicy,
+	namespace string,
+	setName string,
+	binNames []string,
+	taskId int64,
+	handler ScanHandler,
+) *scanHandlerCommand {
+	return &scanHandlerCommand{
+		baseMultiCommand: newMultiCommand(node, nil),
+		policy:           policy,
+		namespace:        namespace,
+		setName:          setName,
+		binNames:         binNames,
+		taskId:           taskId,
+		handler:          handler,
+		digest:           make([]byte, 0, 20),
+	}
+}
+
+func (cmd *scanHandlerCommand) getPolicy(ifc command) Policy {
+	return cmd.policy
+}
+
+func (cmd *scanHandlerCommand) writeBuffer(ifc command) error {
+	return cmd.setScan(cmd.policy, &cmd.namespace, &cmd.setName, cmd.binNames, cmd.taskId)
+}
+
+func (cmd *scanHandlerCommand) parseRecordResults(ifc command, receiveSize int) (bool, error) {
+	// Read/parse remaining message bytes one record at a time.
+	cmd.dataOffset = 0
+
+	for cmd.dataOffset < receiveSize {
+		if err := cmd.readBytes(int(_MSG_REMAINING_HEADER_SIZE)); err != nil {
+			return false, err
+		}
+		resultCode := ResultCode(cmd.dataBuffer[5] & 0xFF)
+
+		if resultCode != 0 {
+			if resultCode == KEY_NOT_FOUND_ERROR {
+				return false, nil
+			}
+			return false, NewAerospikeError(resultCode)
+		}
+
+		info3 := int(cmd.dataBuffer[3])
+
+		// If cmd is the end marker of the response, do not proceed further
+		if (info3 & _INFO3_LAST) == _INFO3_LAST {
+			return false, nil
+		}
+
+		fieldCount := int(uint16(Buffer.BytesToInt16(cmd.dataBuffer, 18)))
+		opCount := int(uint16(Buffer.BytesToInt16(cmd.dataBuffer, 20)))
+
+		if err := cmd.readDigest(fieldCount); err != nil {
+			return false, err
+		}
+
+		for i := 0; i < opCount; i++ {
+			if err := cmd.readBytes(8); err != nil {
+				return false, err
+			}
+
+			opSize := int(uint32(Buffer.BytesToInt32(cmd.dataBuffer, 0)))
+			particleType := int(cmd.dataBuffer[5])
+			nameSize := int(cmd.dataBuffer[7])
+			particleBytesSize := int(opSize - (4 + nameSize))
+
+			// read the name and the value together, so that both remain in the buffer
+			if err := cmd.readBytes(nameSize + particleBytesSize); err != nil {
+				return false, err
+			}
+
+			name := bytesToStringNoCopy(cmd.dataBuffer[:nameSize])
+			raw := cmd.dataBuffer[nameSize : nameSize+particleBytesSize]
+			if err := cmd.handler(cmd.digest, name, particleType, raw); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// readDigest reads the record's fields, and keeps the digest in the command.
+func (cmd *scanHandlerCommand) readDigest(fieldCount int) error {
+	cmd.digest = cmd.digest[:0]
+	for i := 0; i < fieldCount; i++ {
+		if err := cmd.readBytes(4); err != nil {
+			return err
+		}
+
+		fieldlen := int(uint32(Buffer.BytesToInt32(cmd.dataBuffer, 0)))
+		if err := cmd.readBytes(fieldlen); err != nil {
+			return err
+		}
+
+		if FieldType(cmd.dataBuffer[0]) == DIGEST_RIPE {
+			cmd.digest = append(cmd.digest, cmd.dataBuffer[1:fieldlen]...)
+		}
+	}
+	return nil
+}
+
+func (cmd *scanHandlerCommand) parseResult(ifc command, conn *Connection) error {
+	return cmd.baseMultiCommand.parseResult(ifc, conn)
+}
+
+func (cmd *scanHandlerCommand) Execute() error {
+	return cmd.execute(cmd)
+}
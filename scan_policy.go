@@ -31,6 +31,11 @@ type ScanPolicy struct {
 
 	// FailOnClusterChange determines scan termination if cluster is in fluctuating state.
 	FailOnClusterChange bool
+
+	// PredExp determines the predicate expressions, in postfix notation,
+	// which filter the records on the server (Optional).
+	// Predicate expressions are only supported by Aerospike 3.12+ servers.
+	PredExp []PredExp
 }
 
 // NewScanPolicy creates a new ScanPolicy instance with default values.
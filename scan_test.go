@@ -15,8 +15,10 @@
 package aerospike_test
 
 import (
+	"errors"
 	"math"
 	"math/rand"
+	"sync"
 
 	. "github.com/aerospike/aerospike-client-go"
 
@@ -157,6 +159,103 @@ var _ = Describe("Scan operations", func() {
 		Expect(len(keys)).To(Equal(0))
 	})
 
+	It("must Scan all records with a handler, straight from the buffer", func() {
+		Expect(len(keys)).To(Equal(keyCount))
+
+		var mutex sync.Mutex
+		err := client.ScanAllWithHandler(nil, ns, set, func(digest []byte, binName string, particleType int, raw []byte) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			Expect(particleType).To(Equal(bin2.Value.GetType()))
+			Expect(string(raw)).To(Equal(bin2.Value.GetObject()))
+			delete(keys, string(digest))
+			return nil
+		}, bin2.Name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(keys)).To(Equal(0))
+
+		errStop := errors.New("stop")
+		err = client.ScanAllWithHandler(nil, ns, set, func(digest []byte, binName string, particleType int, raw []byte) error {
+			return errStop
+		})
+		Expect(err).To(Equal(errStop))
+	})
+
+	It("must Scan all records with lazily decoded bins", func() {
+		Expect(len(keys)).To(Equal(keyCount))
+
+		scanPolicy := NewScanPolicy()
+		scanPolicy.LazyBins = true
+		recordset, err := client.ScanAll(scanPolicy, ns, set)
+		Expect(err).ToNot(HaveOccurred())
+
+		checkResults(recordset, 0)
+
+		Expect(len(keys)).To(Equal(0))
+	})
+
+	It("must Scan and filter the records on the server using predicate expressions", func() {
+		Expect(len(keys)).To(Equal(keyCount))
+
+		scanPolicy := NewScanPolicy()
+		scanPolicy.PredExp = []PredExp{
+			NewPredExpIntegerBin(bin1.Name),
+			NewPredExpIntegerValue(int64(bin1.Value.GetObject().(int))),
+			NewPredExpIntegerUnequal(),
+		}
+
+		recordset, err := client.ScanAll(scanPolicy, ns, set)
+		Expect(err).ToNot(HaveOccurred())
+
+		for res := range recordset.Results() {
+			Expect(res.Err).ToNot(HaveOccurred())
+			Fail("no records should match the predicate expression")
+		}
+
+		scanPolicy.PredExp = []PredExp{
+			NewPredExpIntegerBin(bin1.Name),
+			NewPredExpIntegerValue(int64(bin1.Value.GetObject().(int))),
+			NewPredExpIntegerEqual(),
+			NewPredExpStringBin(bin2.Name),
+			NewPredExpStringValue(bin2.Value.GetObject().(string)),
+			NewPredExpStringEqual(),
+			NewPredExpAnd(2),
+		}
+
+		recordset, err = client.ScanAll(scanPolicy, ns, set)
+		Expect(err).ToNot(HaveOccurred())
+
+		checkResults(recordset, 0)
+
+		Expect(len(keys)).To(Equal(0))
+	})
+
+	It("must Abort Scan on the server", func() {
+		Expect(len(keys)).To(Equal(keyCount))
+
+		recordset, err := client.ScanAll(nil, ns, set)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(recordset.TaskId()).ToNot(Equal(int64(0)))
+
+		cnt := 0
+		for res := range recordset.Results() {
+			if res.Err != nil {
+				// the scan was aborted on the server
+				break
+			}
+
+			cnt++
+			if cnt == 1 {
+				Expect(client.AbortJob(nil, JOB_SCAN, recordset.TaskId())).ToNot(HaveOccurred())
+			}
+		}
+		recordset.Close()
+
+		// the scan may have finished before the abort reached the server
+		Expect(cnt).To(BeNumerically(">", 0))
+	})
+
 	It("must Cancel Scan", func() {
 		Expect(len(keys)).To(Equal(keyCount))
 
@@ -16,6 +16,7 @@ package aerospike_test
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	. "github.com/aerospike/aerospike-client-go"
@@ -24,6 +25,23 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+type rotatingCredentials struct {
+	mutex          sync.Mutex
+	user, password string
+}
+
+func (c *rotatingCredentials) setPassword(password string) {
+	c.mutex.Lock()
+	c.password = password
+	c.mutex.Unlock()
+}
+
+func (c *rotatingCredentials) Credentials() (string, string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.user, c.password, nil
+}
+
 // ALL tests are isolated by SetName and Key, which are 50 random charachters
 var _ = Describe("Security tests", func() {
 
@@ -92,6 +110,112 @@ var _ = Describe("Security tests", func() {
 
 		}) // describe roles
 
+		Describe("Privileges", func() {
+
+			AfterEach(func() {
+				client.DropRole(nil, "test_role")
+			})
+
+			It("Must Create/Drop Role", func() {
+				// drop before test
+				client.DropRole(nil, "test_role")
+
+				err := client.CreateRole(nil, "test_role", []Privilege{{Code: Read, Namespace: "test", SetName: "demo"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				role, err := client.QueryRole(nil, "test_role")
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(role.Role).To(Equal("test_role"))
+				Expect(role.Privileges).To(ConsistOf(Privilege{Code: Read, Namespace: "test", SetName: "demo"}))
+
+				roles, err := client.QueryRoles(nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				names := []string{}
+				for _, role := range roles {
+					names = append(names, role.Role)
+				}
+				Expect(names).To(ContainElement("test_role"))
+
+				err = client.DropRole(nil, "test_role")
+				Expect(err).ToNot(HaveOccurred())
+
+				role, err = client.QueryRole(nil, "test_role")
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("Must Grant/Revoke Privileges Perfectly", func() {
+				err := client.CreateRole(nil, "test_role", []Privilege{{Code: Read, Namespace: "test"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				err = client.GrantPrivileges(nil, "test_role", []Privilege{{Code: ReadWrite, Namespace: "test", SetName: "demo"}, {Code: DataAdmin}})
+				Expect(err).ToNot(HaveOccurred())
+
+				role, err := client.QueryRole(nil, "test_role")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(role.Privileges).To(ConsistOf(
+					Privilege{Code: Read, Namespace: "test"},
+					Privilege{Code: ReadWrite, Namespace: "test", SetName: "demo"},
+					Privilege{Code: DataAdmin},
+				))
+
+				err = client.RevokePrivileges(nil, "test_role", []Privilege{{Code: Read, Namespace: "test"}})
+				Expect(err).ToNot(HaveOccurred())
+
+				role, err = client.QueryRole(nil, "test_role")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(role.Privileges).To(ConsistOf(
+					Privilege{Code: ReadWrite, Namespace: "test", SetName: "demo"},
+					Privilege{Code: DataAdmin},
+				))
+			})
+
+			It("Must reject scoped global privileges", func() {
+				err := client.CreateRole(nil, "test_role", []Privilege{{Code: SysAdmin, Namespace: "test"}})
+				Expect(err).To(HaveOccurred())
+			})
+
+		}) // describe privileges
+
+		Describe("Credentials Provider", func() {
+
+			It("Must pick up rotated passwords", func() {
+				// drop before test
+				client.DropUser(nil, "test_user")
+
+				err := client.CreateUser(nil, "test_user", "test", []string{"read"})
+				Expect(err).ToNot(HaveOccurred())
+
+				provider := &rotatingCredentials{user: "test_user", password: "test"}
+				client_policy := NewClientPolicy()
+				client_policy.CredentialsProvider = provider
+				new_client, err := NewClientWithPolicy(client_policy, *host, *port)
+				Expect(err).ToNot(HaveOccurred())
+				defer new_client.Close()
+
+				// rotate the password behind the client's back
+				err = client.ChangePassword(nil, "test_user", "test1")
+				Expect(err).ToNot(HaveOccurred())
+				provider.setPassword("test1")
+
+				// new connections must authenticate with the new password
+				for _, node := range new_client.GetNodes() {
+					for i := 0; i < client_policy.ConnectionQueueSize; i++ {
+						conn, err := node.GetConnection(time.Second)
+						Expect(err).ToNot(HaveOccurred())
+						conn.Close()
+					}
+				}
+
+				key, err := NewKey("test", "test", "credentials")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = new_client.Exists(nil, key)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+		}) // describe credentials provider
+
 		Describe("Users", func() {
 
 			It("Must Create/Drop User", func() {
@@ -32,6 +32,15 @@ func newServerCommand(node *Node, policy *QueryPolicy, statement *Statement) *se
 	}
 }
 
+// newServerOperationsCommand creates a command which applies the
+// write operations to the records selected by the statement.
+func newServerOperationsCommand(node *Node, policy *QueryPolicy, writePolicy *WritePolicy, statement *Statement, operations []*Operation) *serverCommand {
+	cmd := newServerCommand(node, policy, statement)
+	cmd.writePolicy = writePolicy
+	cmd.operations = operations
+	return cmd
+}
+
 func (cmd *serverCommand) parseRecordResults(ifc command, receiveSize int) (bool, error) {
 	// Server commands (Query/Execute UDF) should only send back a return code.
 	// Keep parsing logic to empty socket buffer just in case server does
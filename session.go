@@ -0,0 +1,127 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"sync"
+	"time"
+
+	. "github.com/aerospike/aerospike-client-go/types"
+)
+
+// session is the login session of the cluster user on a node.
+// Its token authenticates new connections without the password
+// having to be verified by the server again.
+type session struct {
+	mutex sync.Mutex
+
+	token []byte
+
+	// expiration is the time the session must be renewed by. A zero
+	// expiration means the session does not expire.
+	expiration time.Time
+
+	// legacy is set for servers which do not support login sessions.
+	// Their connections are authenticated with the password.
+	legacy bool
+}
+
+func newSession() *session {
+	return &session{}
+}
+
+func (s *session) set(token []byte, expiration time.Time) {
+	s.mutex.Lock()
+	s.token = token
+	s.expiration = expiration
+	s.mutex.Unlock()
+}
+
+func (s *session) clear() {
+	s.set(nil, time.Time{})
+}
+
+// expired returns true if the session has been established and must be renewed.
+func (s *session) expired() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.token != nil && !s.expiration.IsZero() && time.Now().After(s.expiration)
+}
+
+// login authenticates the connection with the password of the cluster user,
+// and keeps the session token returned by the server.
+func (s *session) login(cluster *Cluster, conn *Connection) error {
+	user, password := cluster.credentials()
+	token, expiration, err := newAdminCommand().login(conn, user, password)
+	if err != nil {
+		if ae, ok := err.(AerospikeError); ok && ae.ResultCode() == INVALID_COMMAND {
+			// server does not support sessions
+			s.mutex.Lock()
+			s.legacy = true
+			s.mutex.Unlock()
+
+			return newAdminCommand().authenticate(conn, user, password)
+		}
+		return err
+	}
+
+	s.set(token, expiration)
+	return nil
+}
+
+// authenticate authenticates a new connection with the session token.
+// If there is no valid session, the user logs in on the connection.
+// If the server rejects the token, the session is cleared so that the
+// next connection logs in again.
+func (s *session) authenticate(cluster *Cluster, conn *Connection) error {
+	s.mutex.Lock()
+	token, legacy := s.token, s.legacy
+	s.mutex.Unlock()
+
+	user, password := cluster.credentials()
+	if legacy {
+		return newAdminCommand().authenticate(conn, user, password)
+	}
+
+	if token == nil || s.expired() {
+		return s.login(cluster, conn)
+	}
+
+	err := newAdminCommand().authenticateSession(conn, user, token)
+	if isSessionRejected(err) {
+		s.clear()
+	}
+	return err
+}
+
+// isCredentialRejected returns true if the server did not accept the user or password.
+func isCredentialRejected(err error) bool {
+	if ae, ok := err.(AerospikeError); ok {
+		switch ae.ResultCode() {
+		case INVALID_USER, INVALID_PASSWORD, EXPIRED_PASSWORD, INVALID_CREDENTIAL:
+			return true
+		}
+	}
+	return false
+}
+
+// isSessionRejected returns true if the server did not accept the session token.
+func isSessionRejected(err error) bool {
+	if ae, ok := err.(AerospikeError); ok {
+		return ae.ResultCode() == EXPIRED_SESSION || ae.ResultCode() == INVALID_CREDENTIAL
+	}
+	return false
+}
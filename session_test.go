@@ -0,0 +1,141 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aerospike
+
+import (
+	"net"
+	"time"
+
+	. "github.com/aerospike/aerospike-client-go/types"
+	Buffer "github.com/aerospike/aerospike-client-go/utils/buffer"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// fakeAdminServer reads an admin request from the connection, and sends
+// its fields to the channel, with the command under key 255. It then
+// replies with the result code and the response fields.
+func fakeAdminServer(conn net.Conn, requests chan map[byte][]byte, result ResultCode, fields map[byte][]byte) {
+	defer GinkgoRecover()
+
+	buf := make([]byte, 1024)
+	_, err := (&Connection{conn: conn}).Read(buf, 8)
+	Expect(err).ToNot(HaveOccurred())
+	size := int(Buffer.BytesToInt64(buf, 0) & 0xFFFFFFFFFFFF)
+	_, err = (&Connection{conn: conn}).Read(buf, size)
+	Expect(err).ToNot(HaveOccurred())
+
+	request := map[byte][]byte{255: {buf[2]}}
+	for offset := _HEADER_REMAINING; offset < size; {
+		len := int(Buffer.BytesToInt32(buf, offset)) - 1
+		request[buf[offset+4]] = append([]byte{}, buf[offset+5:offset+5+len]...)
+		offset += len + 5
+	}
+	requests <- request
+
+	acmd := newAdminCommand()
+	acmd.dataOffset = 8
+	acmd.writeHeader(0, len(fields))
+	acmd.dataBuffer[9] = byte(result)
+	for id, value := range fields {
+		acmd.writeFieldBytes(id, value)
+	}
+	acmd.writeSize()
+	_, err = conn.Write(acmd.dataBuffer[:acmd.dataOffset])
+	Expect(err).ToNot(HaveOccurred())
+}
+
+var _ = Describe("Session Test", func() {
+
+	var cluster = &Cluster{user: "user", password: []byte("hash")}
+
+	serve := func(result ResultCode, fields map[byte][]byte) (*Connection, chan map[byte][]byte) {
+		client, server := net.Pipe()
+		requests := make(chan map[byte][]byte, 1)
+		go fakeAdminServer(server, requests, result, fields)
+		return &Connection{conn: client}, requests
+	}
+
+	It("should log in and authenticate new connections with the session token", func() {
+		s := newSession()
+
+		ttl := make([]byte, 4)
+		Buffer.Int32ToBytes(3600, ttl, 0)
+		conn, requests := serve(0, map[byte][]byte{_SESSION_TOKEN: []byte("token"), _SESSION_TTL: ttl})
+
+		Expect(s.authenticate(cluster, conn)).ToNot(HaveOccurred())
+		request := <-requests
+		Expect(request[255]).To(Equal([]byte{_LOGIN}))
+		Expect(request[_USER]).To(Equal([]byte("user")))
+		Expect(request[_CREDENTIAL]).To(Equal([]byte("hash")))
+
+		Expect(s.token).To(Equal([]byte("token")))
+		Expect(s.expiration).To(BeTemporally("~", time.Now().Add(3540*time.Second), time.Second))
+		Expect(s.expired()).To(BeFalse())
+
+		conn, requests = serve(0, nil)
+		Expect(s.authenticate(cluster, conn)).ToNot(HaveOccurred())
+		request = <-requests
+		Expect(request[255]).To(Equal([]byte{_AUTHENTICATE}))
+		Expect(request[_SESSION_TOKEN]).To(Equal([]byte("token")))
+		Expect(request).ToNot(HaveKey(_CREDENTIAL))
+	})
+
+	It("should log in again when the session has expired", func() {
+		s := newSession()
+		s.set([]byte("old"), time.Now().Add(-time.Second))
+		Expect(s.expired()).To(BeTrue())
+
+		conn, requests := serve(0, map[byte][]byte{_SESSION_TOKEN: []byte("new")})
+		Expect(s.authenticate(cluster, conn)).ToNot(HaveOccurred())
+		Expect((<-requests)[255]).To(Equal([]byte{_LOGIN}))
+
+		Expect(s.token).To(Equal([]byte("new")))
+		// no TTL; the session does not expire
+		Expect(s.expired()).To(BeFalse())
+	})
+
+	It("should clear the session when the server rejects the token", func() {
+		s := newSession()
+		s.set([]byte("token"), time.Time{})
+
+		conn, requests := serve(EXPIRED_SESSION, nil)
+		err := s.authenticate(cluster, conn)
+		<-requests
+		Expect(isSessionRejected(err)).To(BeTrue())
+		Expect(s.token).To(BeNil())
+	})
+
+	It("should fall back to password authentication if the server does not support sessions", func() {
+		s := newSession()
+
+		client, server := net.Pipe()
+		conn := &Connection{conn: client}
+		requests := make(chan map[byte][]byte, 2)
+		go func() {
+			fakeAdminServer(server, requests, INVALID_COMMAND, nil)
+			fakeAdminServer(server, requests, 0, nil)
+		}()
+
+		Expect(s.authenticate(cluster, conn)).ToNot(HaveOccurred())
+		Expect((<-requests)[255]).To(Equal([]byte{_LOGIN}))
+		request := <-requests
+		Expect(request[255]).To(Equal([]byte{_AUTHENTICATE}))
+		Expect(request[_CREDENTIAL]).To(Equal([]byte("hash")))
+		Expect(s.legacy).To(BeTrue())
+	})
+
+})
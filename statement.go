@@ -40,6 +40,11 @@ type Statement struct {
 	// aggregation function.
 	Filters []*Filter
 
+	// PredExp determines the predicate expressions, in postfix notation,
+	// which filter the records on the server (Optional).
+	// Unlike Filters, predicate expressions do not require a secondary index.
+	PredExp []PredExp
+
 	packageName  string
 	functionName string
 	functionArgs []Value
@@ -58,7 +63,7 @@ func NewStatement(ns string, set string, binNames ...string) *Statement {
 		SetName:    set,
 		BinNames:   binNames,
 		returnData: true,
-		TaskId:     xornd.Int64(),
+		TaskId:     newTaskId(),
 	}
 }
 
@@ -69,6 +74,15 @@ func (stmt *Statement) Addfilter(filter *Filter) error {
 	return nil
 }
 
+// SetPredExp sets the predicate expressions of the statement.
+// The expressions must be listed in postfix notation.
+// Predicate expressions are only supported by Aerospike 3.12+ servers.
+func (stmt *Statement) SetPredExp(predExp ...PredExp) error {
+	stmt.PredExp = predExp
+
+	return nil
+}
+
 // SetAggregateFunction sets aggregation function parameters.
 // This function will be called on both the server
 // and client for each selected item.
@@ -86,7 +100,16 @@ func (stmt *Statement) IsScan() bool {
 
 // Always set the taskId client-side to a non-zero random value
 func (stmt *Statement) setTaskId() {
-	for stmt.TaskId == 0 {
-		stmt.TaskId = xornd.Int64()
+	if stmt.TaskId == 0 {
+		stmt.TaskId = newTaskId()
+	}
+}
+
+// newTaskId returns a non-zero random task id.
+func newTaskId() int64 {
+	taskId := xornd.Int64()
+	for taskId == 0 {
+		taskId = xornd.Int64()
 	}
+	return taskId
 }
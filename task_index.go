@@ -15,17 +15,28 @@
 package aerospike
 
 import (
-	"regexp"
 	"strconv"
-	"strings"
+	"sync"
+
+	. "github.com/aerospike/aerospike-client-go/types"
 )
 
+// The index may not be visible on all nodes right after it is created.
+// Polls which do not find the index on a node are retried this many times
+// before the task fails.
+const _INDEX_NOT_FOUND_RETRIES = 10
+
 // IndexTask is used to poll for long running create index completion.
 type IndexTask struct {
 	*BaseTask
 
 	namespace string
 	indexName string
+
+	// notFound counts the polls which did not find the index, per node.
+	// IsDone and Progress may be called from different goroutines.
+	notFound      map[string]int
+	notFoundMutex sync.Mutex
 }
 
 // NewIndexTask initializes a task with fields needed to query server nodes.
@@ -34,43 +45,98 @@ func NewIndexTask(cluster *Cluster, namespace string, indexName string) *IndexTa
 		BaseTask:  NewTask(cluster, false),
 		namespace: namespace,
 		indexName: indexName,
+		notFound:  map[string]int{},
+	}
+}
+
+// loadPercent returns the percentage of the index built on the node.
+func (tski *IndexTask) loadPercent(node *Node) (int, error) {
+	stats, err := requestNodeIndexStats(node, _DEFAULT_TIMEOUT, tski.namespace, tski.indexName)
+	if err != nil {
+		if ae, ok := err.(AerospikeError); ok && ae.ResultCode() == INDEX_NOTFOUND && tski.retryNotFound(node) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	pct, err := indexLoadPercent(stats)
+	if err != nil {
+		return 0, NewAerospikeError(INDEX_GENERIC, "Index "+tski.indexName+" failed on node "+node.GetName()+": "+err.Error())
+	}
+	return pct, nil
+}
+
+// retryNotFound counts a poll which did not find the index on the node,
+// and returns false if the index has not been found too many times.
+func (tski *IndexTask) retryNotFound(node *Node) bool {
+	tski.notFoundMutex.Lock()
+	defer tski.notFoundMutex.Unlock()
+
+	if tski.notFound[node.GetName()] >= _INDEX_NOT_FOUND_RETRIES {
+		return false
+	}
+	tski.notFound[node.GetName()]++
+	return true
+}
+
+// indexLoadPercent returns the percentage of the index built, from the index
+// statistics of a node. An error is returned if the state of the index is
+// neither "RW" nor "WO", which means that building the index has failed.
+func indexLoadPercent(stats map[string]string) (int, error) {
+	state, hasState := stats["state"]
+	if hasState && state != "RW" && state != "WO" {
+		return 0, NewAerospikeError(INDEX_GENERIC, "index state is "+state)
 	}
+
+	if pct, exists := stats["load_pct"]; exists {
+		return strconv.Atoi(pct)
+	}
+
+	if state == "WO" {
+		return 0, nil
+	}
+	return 100, nil
 }
 
 // IsDone queries all nodes for task completion status.
+// An error is returned if building the index has failed on a node.
 func (tski *IndexTask) IsDone() (bool, error) {
-	command := "sindex/" + tski.namespace + "/" + tski.indexName
 	nodes := tski.cluster.GetNodes()
 	complete := false
 
-	r := regexp.MustCompile(`\.*load_pct=(\d+)\.*`)
-
 	for _, node := range nodes {
-		responseMap, err := RequestNodeInfo(node, command)
+		pct, err := tski.loadPercent(node)
 		if err != nil {
 			return false, err
 		}
 
-		for _, response := range responseMap {
-			find := "load_pct="
-			index := strings.Index(response, find)
-
-			if index < 0 {
-				complete = true
-				continue
-			}
+		if pct >= 0 && pct < 100 {
+			return false, nil
+		}
+		complete = true
+	}
+	return complete, nil
+}
 
-			matchRes := r.FindStringSubmatch(response)
-			// we know it exists and is a valid number
-			pct, _ := strconv.Atoi(matchRes[1])
+// Progress queries all nodes for the task progress, and returns the
+// percentage of the index built on the cluster.
+// An error is returned if building the index has failed on a node.
+func (tski *IndexTask) Progress() (int, error) {
+	nodes := tski.cluster.GetNodes()
+	if len(nodes) == 0 {
+		return 0, NewAerospikeError(SERVER_NOT_AVAILABLE, "Progress failed because cluster is empty.")
+	}
 
-			if pct >= 0 && pct < 100 {
-				return false, nil
-			}
-			complete = true
+	total := 0
+	for _, node := range nodes {
+		pct, err := tski.loadPercent(node)
+		if err != nil {
+			return 0, err
 		}
+		total += pct
 	}
-	return complete, nil
+
+	return total / len(nodes), nil
 }
 
 // OnComplete returns a channel that will be closed as soon as the task is finished.
@@ -0,0 +1,547 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Asgen generates the ToBins and FromBins methods of structs, which PutObject
+// and GetObject use in place of reflection.
+//
+// Add a go:generate directive to the file declaring the types:
+//
+//	//go:generate asgen -type=Person,Address
+//
+// and run go generate. The methods are written to <type>_asgen.go, or to the
+// file set by -output.
+//
+// Fields are mapped to bins like PutObject does, honoring the `as` tag with the
+// omitempty and json options. Supported field types are strings, booleans,
+// integers, floats, []byte and time.Time; other types must be stored as json,
+// or excluded with `as:"-"`. For integer fields tagged with `asm:"gen"` or
+// `asm:"ttl"`, the MetadataWritePolicy and SetMetadata methods are generated
+// as well.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var typeNames = flag.String("type", "", "comma-separated list of type names; must be set")
+var output = flag.String("output", "", "output file name; default srcdir/<type>_asgen.go")
+var internal = flag.Bool("internal", false, "generate code for the aerospike package itself")
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage of asgen:\n")
+	fmt.Fprintf(os.Stderr, "\tasgen [flags] -type T [directory]\n")
+	fmt.Fprintf(os.Stderr, "Flags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("asgen: ")
+	flag.Usage = usage
+	flag.Parse()
+
+	if *typeNames == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dir := "."
+	if args := flag.Args(); len(args) > 0 {
+		dir = args[0]
+	}
+
+	g := &generator{internal: *internal}
+	if err := g.parse(dir, strings.Split(*typeNames, ",")); err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	src, err := g.generate()
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+
+	outputName := *output
+	if outputName == "" {
+		suffix := "_asgen.go"
+		if g.isTest {
+			suffix = "_asgen_test.go"
+		}
+		outputName = filepath.Join(dir, strings.ToLower(g.types[0].name)+suffix)
+	}
+
+	if err := ioutil.WriteFile(outputName, src, 0644); err != nil {
+		log.Fatalln(err.Error())
+	}
+}
+
+// fieldKind is the kind of value a field is converted to and from.
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindBool
+	kindInt
+	kindLong
+	kindUint
+	kindFloat
+	kindBytes
+	kindTime
+	kindJSON
+)
+
+var basicKinds = map[string]fieldKind{
+	"string":  kindString,
+	"bool":    kindBool,
+	"int":     kindInt,
+	"int8":    kindLong,
+	"int16":   kindLong,
+	"int32":   kindLong,
+	"int64":   kindLong,
+	"uint8":   kindLong,
+	"byte":    kindLong,
+	"uint16":  kindLong,
+	"uint32":  kindLong,
+	"uint":    kindUint,
+	"uint64":  kindUint,
+	"float32": kindFloat,
+	"float64": kindFloat,
+}
+
+type field struct {
+	name      string
+	alias     string
+	typeName  string
+	kind      fieldKind
+	omitEmpty bool
+}
+
+type structType struct {
+	name   string
+	fields []*field
+
+	// the fields tagged with `asm:"gen"` and `asm:"ttl"`, if any
+	genField *field
+	ttlField *field
+}
+
+type generator struct {
+	internal bool
+	pkgName  string
+	isTest   bool
+	types    []*structType
+
+	// the standard packages, and whether the types package, the generated
+	// code uses; unused imports fail the build
+	imports   map[string]bool
+	typesUsed bool
+}
+
+// parse finds the struct types in the package in dir.
+func (g *generator) parse(dir string, names []string) error {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	// sort the package names so the result does not depend on the map order
+	pkgNames := make([]string, 0, len(pkgs))
+	for name := range pkgs {
+		pkgNames = append(pkgNames, name)
+	}
+	sort.Strings(pkgNames)
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+
+		var spec *ast.StructType
+		for _, pkgName := range pkgNames {
+			for fileName, file := range pkgs[pkgName].Files {
+				if s := findStruct(file, name); s != nil {
+					if g.pkgName != "" && g.pkgName != pkgName {
+						return fmt.Errorf("types %s are not in the same package", *typeNames)
+					}
+					spec = s
+					g.pkgName = pkgName
+					g.isTest = strings.HasSuffix(fileName, "_test.go")
+				}
+			}
+		}
+
+		if spec == nil {
+			return fmt.Errorf("struct type %s not found in %s", name, dir)
+		}
+
+		st, err := newStructType(name, spec)
+		if err != nil {
+			return err
+		}
+		g.types = append(g.types, st)
+	}
+	return nil
+}
+
+func findStruct(file *ast.File, name string) *ast.StructType {
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if ts.Name.Name != name {
+				continue
+			}
+
+			if st, ok := ts.Type.(*ast.StructType); ok {
+				return st
+			}
+		}
+	}
+	return nil
+}
+
+func newStructType(name string, spec *ast.StructType) (*structType, error) {
+	st := &structType{name: name}
+	for _, f := range spec.Fields.List {
+		var tag reflect.StructTag
+		if f.Tag != nil {
+			s, err := strconv.Unquote(f.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			tag = reflect.StructTag(s)
+		}
+
+		// metadata fields are not persisted
+		if meta := strings.TrimSpace(tag.Get("asm")); meta != "" {
+			if err := st.addMetadataField(meta, f); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		parts := strings.Split(tag.Get("as"), ",")
+		alias := strings.TrimSpace(parts[0])
+		if alias == "-" {
+			continue
+		}
+
+		if len(f.Names) == 0 {
+			return nil, fmt.Errorf("%s: embedded fields are not supported", name)
+		}
+
+		for _, ident := range f.Names {
+			// skip unexported fields
+			if !ident.IsExported() {
+				continue
+			}
+
+			fld := &field{name: ident.Name, alias: alias, typeName: exprString(f.Type)}
+			if fld.alias == "" {
+				fld.alias = ident.Name
+			}
+
+			kind, ok := fieldKindOf(f.Type)
+			for _, option := range parts[1:] {
+				switch strings.TrimSpace(option) {
+				case "omitempty":
+					fld.omitEmpty = true
+				case "json":
+					kind, ok = kindJSON, true
+				case "msgpack":
+					return nil, fmt.Errorf("%s.%s: the msgpack option is not supported", name, ident.Name)
+				}
+			}
+
+			if !ok {
+				return nil, fmt.Errorf("%s.%s: type %s is not supported; store it as json or exclude it with `as:\"-\"`", name, ident.Name, fld.typeName)
+			}
+
+			if kind == kindJSON && fld.omitEmpty {
+				return nil, fmt.Errorf("%s.%s: the omitempty option is not supported with json", name, ident.Name)
+			}
+
+			fld.kind = kind
+			st.fields = append(st.fields, fld)
+		}
+	}
+	return st, nil
+}
+
+// addMetadataField sets the field tagged with the `asm` tag meta.
+func (st *structType) addMetadataField(meta string, f *ast.Field) error {
+	for _, ident := range f.Names {
+		if !ident.IsExported() {
+			continue
+		}
+
+		fld := &field{name: ident.Name, typeName: exprString(f.Type)}
+		if kind, ok := fieldKindOf(f.Type); !ok || (kind != kindInt && kind != kindLong && kind != kindUint) {
+			return fmt.Errorf("%s.%s: metadata fields must be integers", st.name, ident.Name)
+		}
+
+		switch meta {
+		case "gen":
+			st.genField = fld
+		case "ttl":
+			st.ttlField = fld
+		}
+	}
+	return nil
+}
+
+func fieldKindOf(expr ast.Expr) (fieldKind, bool) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		kind, ok := basicKinds[t.Name]
+		return kind, ok
+	case *ast.ArrayType:
+		if elt, ok := t.Elt.(*ast.Ident); ok && t.Len == nil && (elt.Name == "byte" || elt.Name == "uint8") {
+			return kindBytes, true
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := t.X.(*ast.Ident); ok && pkg.Name == "time" && t.Sel.Name == "Time" {
+			return kindTime, true
+		}
+	}
+	return 0, false
+}
+
+func exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	format.Node(&buf, token.NewFileSet(), expr)
+	return buf.String()
+}
+
+// convert returns the expression of type from converted to the type named to.
+func convert(to, from, expr string) string {
+	if to == from {
+		return expr
+	}
+	return to + "(" + expr + ")"
+}
+
+// client returns the name qualified with the client package.
+func (g *generator) client(name string) string {
+	if g.internal {
+		return name
+	}
+	return "as." + name
+}
+
+func (g *generator) generate() ([]byte, error) {
+	g.imports = map[string]bool{}
+	g.typesUsed = false
+
+	var body bytes.Buffer
+	for _, st := range g.types {
+		g.generateToBins(&body, st)
+		g.generateFromBins(&body, st)
+		g.generateMetadata(&body, st)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by \"asgen %s\"; DO NOT EDIT.\n\n", strings.Join(os.Args[1:], " "))
+	fmt.Fprintf(&buf, "package %s\n\n", g.pkgName)
+	fmt.Fprintf(&buf, "import (\n")
+
+	imports := make([]string, 0, len(g.imports))
+	for imp := range g.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	for _, imp := range imports {
+		fmt.Fprintf(&buf, "\t%q\n", imp)
+	}
+
+	fmt.Fprintf(&buf, "\n")
+	if !g.internal {
+		fmt.Fprintf(&buf, "\tas \"github.com/aerospike/aerospike-client-go\"\n")
+	}
+	if g.typesUsed {
+		if g.internal {
+			fmt.Fprintf(&buf, "\t. \"github.com/aerospike/aerospike-client-go/types\"\n")
+		} else {
+			fmt.Fprintf(&buf, "\t\"github.com/aerospike/aerospike-client-go/types\"\n")
+		}
+	}
+	fmt.Fprintf(&buf, ")\n")
+	buf.Write(body.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+func (g *generator) generateToBins(buf *bytes.Buffer, st *structType) {
+	fmt.Fprintf(buf, "\n// ToBins implements %s.\n", g.client("ObjectMarshaler"))
+	fmt.Fprintf(buf, "func (o *%s) ToBins() ([]*%s, error) {\n", st.name, g.client("Bin"))
+	fmt.Fprintf(buf, "bins := make([]*%s, 0, %d)\n", g.client("Bin"), len(st.fields))
+
+	for _, f := range st.fields {
+		fld := "o." + f.name
+
+		var cond, value string
+		switch f.kind {
+		case kindString:
+			cond, value = fld+` != ""`, g.client("NewStringValue")+"("+fld+")"
+		case kindBool:
+			cond, value = fld, g.client("NewBoolValue")+"("+fld+")"
+		case kindInt:
+			cond, value = fld+" != 0", g.client("NewIntegerValue")+"("+fld+")"
+		case kindLong:
+			cond, value = fld+" != 0", g.client("NewLongValue")+"(int64("+fld+"))"
+		case kindUint:
+			cond, value = fld+" != 0", g.client("NewValue")+"("+fld+")"
+		case kindFloat:
+			cond, value = fld+" != 0", g.client("NewFloatValue")+"("+convert("float64", f.typeName, fld)+")"
+		case kindBytes:
+			cond, value = "len("+fld+") != 0", g.client("NewBytesValue")+"("+fld+")"
+		case kindTime:
+			cond, value = "!"+fld+".IsZero()", g.client("NewLongValue")+"("+fld+".UTC().UnixNano())"
+		case kindJSON:
+			g.imports["encoding/json"] = true
+			b := strings.ToLower(f.name[:1]) + f.name[1:] + "JSON"
+			fmt.Fprintf(buf, "%s, err := json.Marshal(%s)\nif err != nil {\nreturn nil, err\n}\n", b, fld)
+			fmt.Fprintf(buf, "bins = append(bins, &%s{Name: %q, Value: %s(%s)})\n", g.client("Bin"), f.alias, g.client("NewBytesValue"), b)
+			continue
+		}
+
+		// nil slices and zero times are not stored, like PutObject does
+		switch {
+		case f.omitEmpty:
+		case f.kind == kindBytes:
+			cond = fld + " != nil"
+		case f.kind == kindTime:
+		default:
+			cond = ""
+		}
+
+		stmt := fmt.Sprintf("bins = append(bins, &%s{Name: %q, Value: %s})\n", g.client("Bin"), f.alias, value)
+		if cond != "" {
+			fmt.Fprintf(buf, "if %s {\n%s}\n", cond, stmt)
+		} else {
+			buf.WriteString(stmt)
+		}
+	}
+
+	fmt.Fprintf(buf, "return bins, nil\n}\n")
+}
+
+func (g *generator) generateFromBins(buf *bytes.Buffer, st *structType) {
+	fmt.Fprintf(buf, "\n// FromBins implements %s.\n", g.client("ObjectUnmarshaler"))
+	fmt.Fprintf(buf, "func (o *%s) FromBins(bins %s) error {\n", st.name, g.client("BinMap"))
+	if len(st.fields) == 0 {
+		fmt.Fprintf(buf, "return nil\n}\n")
+		return
+	}
+
+	fmt.Fprintf(buf, "for name, value := range bins {\n")
+	fmt.Fprintf(buf, "switch name {\n")
+
+	for _, f := range st.fields {
+		fld := "o." + f.name
+		fmt.Fprintf(buf, "case %q:\n", f.alias)
+		fmt.Fprintf(buf, "switch v := value.(type) {\n")
+		fmt.Fprintf(buf, "case nil:\n")
+
+		switch f.kind {
+		case kindString:
+			fmt.Fprintf(buf, "case string:\n%s = v\n", fld)
+		case kindBool:
+			// older clients stored booleans as integers
+			fmt.Fprintf(buf, "case bool:\n%s = v\n", fld)
+			fmt.Fprintf(buf, "case int:\n%s = v != 0\n", fld)
+		case kindInt, kindLong:
+			fmt.Fprintf(buf, "case int:\n%s = %s\n", fld, convert(f.typeName, "int", "v"))
+		case kindUint:
+			// values over math.MaxInt64 are stored as big integers
+			g.imports["math/big"] = true
+			fmt.Fprintf(buf, "case int:\n%s = %s(v)\n", fld, f.typeName)
+			fmt.Fprintf(buf, "case []byte:\n%s = %s\n", fld, convert(f.typeName, "uint64", "new(big.Int).SetBytes(v).Uint64()"))
+		case kindFloat:
+			// older clients stored floats as their integer bits
+			g.imports["math"] = true
+			fmt.Fprintf(buf, "case float64:\n%s = %s\n", fld, convert(f.typeName, "float64", "v"))
+			fmt.Fprintf(buf, "case int:\n%s = %s\n", fld, convert(f.typeName, "float64", "math.Float64frombits(uint64(v))"))
+		case kindBytes:
+			fmt.Fprintf(buf, "case []byte:\n%s = v\n", fld)
+		case kindTime:
+			g.imports["time"] = true
+			fmt.Fprintf(buf, "case int:\n%s = time.Unix(0, int64(v))\n", fld)
+		case kindJSON:
+			g.imports["encoding/json"] = true
+			fmt.Fprintf(buf, "case []byte:\nif err := json.Unmarshal(v, &%s); err != nil {\nreturn err\n}\n", fld)
+		}
+
+		g.imports["fmt"] = true
+		fmt.Fprintf(buf, "default:\n")
+		fmt.Fprintf(buf, "return %s(%s, fmt.Sprintf(\"Can not set value of type %%T to %s.%s\", value))\n",
+			g.typesName("NewAerospikeError"), g.typesName("PARSE_ERROR"), st.name, f.name)
+		fmt.Fprintf(buf, "}\n")
+	}
+
+	fmt.Fprintf(buf, "}\n}\nreturn nil\n}\n")
+}
+
+func (g *generator) generateMetadata(buf *bytes.Buffer, st *structType) {
+	if st.genField == nil && st.ttlField == nil {
+		return
+	}
+
+	fmt.Fprintf(buf, "\n// MetadataWritePolicy implements %s.\n", g.client("ObjectMetadata"))
+	fmt.Fprintf(buf, "func (o *%s) MetadataWritePolicy(policy *%s) *%s {\n", st.name, g.client("WritePolicy"), g.client("WritePolicy"))
+	fmt.Fprintf(buf, "res := *policy\n")
+	if f := st.genField; f != nil {
+		fmt.Fprintf(buf, "if o.%s != 0 {\nres.Generation = %s\nres.GenerationPolicy = %s\n}\n", f.name, convert("int32", f.typeName, "o."+f.name), g.client("EXPECT_GEN_EQUAL"))
+	}
+	if f := st.ttlField; f != nil {
+		fmt.Fprintf(buf, "if o.%s != 0 {\nres.Expiration = %s\n}\n", f.name, convert("int32", f.typeName, "o."+f.name))
+	}
+	fmt.Fprintf(buf, "return &res\n}\n")
+
+	fmt.Fprintf(buf, "\n// SetMetadata implements %s.\n", g.client("ObjectMetadata"))
+	fmt.Fprintf(buf, "func (o *%s) SetMetadata(generation, expiration int) {\n", st.name)
+	if f := st.genField; f != nil {
+		fmt.Fprintf(buf, "o.%s = %s\n", f.name, convert(f.typeName, "int", "generation"))
+	}
+	if f := st.ttlField; f != nil {
+		fmt.Fprintf(buf, "o.%s = %s\n", f.name, convert(f.typeName, "int", "expiration"))
+	}
+	fmt.Fprintf(buf, "}\n")
+}
+
+// typesName returns the name qualified with the types package,
+// and adds the package to the imports.
+func (g *generator) typesName(name string) string {
+	g.typesUsed = true
+	if g.internal {
+		return name
+	}
+	return "types." + name
+}
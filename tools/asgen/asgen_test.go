@@ -0,0 +1,95 @@
+// Copyright 2013-2014 Aerospike, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"go/build"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Asgen", func() {
+
+	var gopath string
+
+	BeforeEach(func() {
+		var err error
+		gopath, err = ioutil.TempDir("", "asgen")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(gopath)
+	})
+
+	// generate writes the source to a package in the temporary GOPATH,
+	// generates the methods of the types, and builds the package.
+	generate := func(pkg, src string, types ...string) string {
+		dir := filepath.Join(gopath, "src", pkg)
+		Expect(os.MkdirAll(dir, 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(dir, "types.go"), []byte(src), 0644)).To(Succeed())
+
+		g := &generator{}
+		Expect(g.parse(dir, types)).To(Succeed())
+		generated, err := g.generate()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ioutil.WriteFile(filepath.Join(dir, "types_asgen.go"), generated, 0644)).To(Succeed())
+
+		cmd := exec.Command("go", "build", "-o", os.DevNull, pkg)
+		cmd.Env = append(os.Environ(), "GOPATH="+gopath+string(filepath.ListSeparator)+build.Default.GOPATH, "GO111MODULE=off")
+		out, err := cmd.CombinedOutput()
+		Expect(err).ToNot(HaveOccurred(), string(out))
+
+		return string(generated)
+	}
+
+	It("should generate code which builds in other packages", func() {
+		generated := generate("asgentest/people", `package people
+
+import "time"
+
+type Person struct {
+	Name     string            `+"`as:\"name\"`"+`
+	Age      int
+	Score    float32
+	Created  time.Time
+	Settings map[string]string `+"`as:\"settings,json\"`"+`
+
+	Generation uint32 `+"`asm:\"gen\"`"+`
+}
+`, "Person")
+
+		Expect(generated).To(ContainSubstring("types.NewAerospikeError(types.PARSE_ERROR,"))
+		Expect(generated).To(ContainSubstring("as.EXPECT_GEN_EQUAL"))
+	})
+
+	It("should only import the packages the generated code uses", func() {
+		generated := generate("asgentest/empty", `package empty
+
+type Empty struct {
+	Ignored string `+"`as:\"-\"`"+`
+}
+`, "Empty")
+
+		Expect(generated).ToNot(ContainSubstring(`"fmt"`))
+		Expect(generated).ToNot(ContainSubstring(`"github.com/aerospike/aerospike-client-go/types"`))
+	})
+
+})
@@ -145,6 +145,9 @@ const (
 	// Security credential is invalid.
 	INVALID_CREDENTIAL ResultCode = 65
 
+	// Login session expired.
+	EXPIRED_SESSION ResultCode = 66
+
 	// Role name is invalid.
 	INVALID_ROLE ResultCode = 70
 
@@ -355,6 +358,9 @@ func ResultCodeToString(resultCode ResultCode) string {
 	case INVALID_CREDENTIAL:
 		return "Invalid credential"
 
+	case EXPIRED_SESSION:
+		return "Login session expired"
+
 	case INVALID_ROLE:
 		return "Invalid role"
 
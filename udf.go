@@ -1,5 +1,13 @@
 package aerospike
 
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
 // UDF carries information about UDFs on the server
 type UDF struct {
 	// Filename of the UDF
@@ -9,3 +17,33 @@ type UDF struct {
 	// Language of UDF
 	Language Language
 }
+
+// udfHash returns the digest of the UDF body, as reported by the server in ListUDF.
+func udfHash(udfBody []byte) string {
+	hash := sha1.Sum(udfBody)
+	return hex.EncodeToString(hash[:])
+}
+
+// readUDFDir reads the Lua modules in the directory, indexed by file name.
+// Subdirectories are not included.
+func readUDFDir(dir string) (map[string][]byte, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string][]byte, len(files))
+	for _, file := range files {
+		if file.IsDir() || strings.ToLower(filepath.Ext(file.Name())) != ".lua" {
+			continue
+		}
+
+		udfBody, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		res[file.Name()] = udfBody
+	}
+
+	return res, nil
+}
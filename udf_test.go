@@ -15,8 +15,11 @@
 package aerospike_test
 
 import (
+	"io/ioutil"
 	"math"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"strings"
 
 	. "github.com/aerospike/aerospike-client-go"
@@ -129,6 +132,46 @@ var _ = Describe("UDF/Query tests", func() {
 		Expect(err.Error()).To(Equal("error=file_not_found"))
 	})
 
+	It("must get the body of a udf from the server", func() {
+		body, err := client.GetUDF(nil, "udf1.lua")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal(udfBody))
+
+		_, err = client.GetUDF(nil, randString(10)+".lua")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("must sync the udfs in a directory with the server", func() {
+		dir, err := ioutil.TempDir("", "udfs")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		name := randString(10) + ".lua"
+		err = ioutil.WriteFile(filepath.Join(dir, "udf1.lua"), []byte(udfBody), 0644)
+		Expect(err).ToNot(HaveOccurred())
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(udfEcho), 0644)
+		Expect(err).ToNot(HaveOccurred())
+
+		// udf1.lua is already registered with the same body
+		registered, removed, err := client.SyncUDFs(wpolicy, dir, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(registered).To(Equal([]string{name}))
+		Expect(removed).To(BeEmpty())
+
+		body, err := client.GetUDF(nil, name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal(udfEcho))
+
+		// nothing has changed since the last sync
+		registered, _, err = client.SyncUDFs(wpolicy, dir, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(registered).To(BeEmpty())
+
+		delTask, err := client.RemoveUDF(wpolicy, name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(<-delTask.OnComplete()).ToNot(HaveOccurred())
+	})
+
 	Context("must run the UDF on all records", func() {
 
 		BeforeEach(func() {
@@ -163,6 +206,80 @@ var _ = Describe("UDF/Query tests", func() {
 			}
 		})
 
+		It("must list and monitor the background jobs", func() {
+			statement := NewStatement(ns, set)
+			exTask, err := client.ExecuteUDF(nil, statement, "udf1", "testFunc1", NewValue(2))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exTask.TaskId()).To(Equal(statement.TaskId))
+
+			// wait until UDF is run on all records
+			Expect(<-exTask.OnComplete()).ToNot(HaveOccurred())
+
+			progress, err := exTask.Progress()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(progress).To(Equal(100))
+
+			jobs, err := client.ListJobs(nil, JOB_SCAN)
+			Expect(err).ToNot(HaveOccurred())
+
+			found := false
+			for _, job := range jobs {
+				if job.TaskId == exTask.TaskId() {
+					found = true
+				}
+			}
+			Expect(found).To(BeTrue())
+
+			jobs, err = client.GetJobInfo(nil, JOB_SCAN, exTask.TaskId())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(len(jobs)).To(BeNumerically(">", 0))
+			for _, job := range jobs {
+				Expect(job.Status).To(Equal(JOB_STATUS_DONE))
+				Expect(job.Namespace).To(Equal(ns))
+			}
+
+			// finished jobs are not aborted
+			Expect(client.AbortJob(nil, JOB_SCAN, exTask.TaskId())).ToNot(HaveOccurred())
+		})
+
+		It("must run operations on all records in the background", func() {
+			statement := NewStatement(ns, set)
+
+			bpolicy := NewWritePolicy(0, 1000)
+			exTask, err := client.ExecuteOperations(bpolicy, statement, PutOp(NewBin("bgbin", "bgvalue")), AddOp(NewBin(bin1.Name, 1)))
+			Expect(err).ToNot(HaveOccurred())
+
+			// wait until the operations are run on all records
+			Expect(<-exTask.OnComplete()).ToNot(HaveOccurred())
+
+			// read all data and make sure it is consistent
+			recordset, err := client.ScanAll(nil, ns, set)
+			Expect(err).ToNot(HaveOccurred())
+
+			i := 0
+			for fullRec := range recordset.Records {
+				i++
+				Expect(fullRec.Bins["bgbin"]).To(Equal("bgvalue"))
+				Expect(fullRec.Bins[bin1.Name]).To(Equal(bin1.Value.GetObject().(int) + 1))
+				Expect(fullRec.Expiration).To(BeNumerically("<=", 1000))
+			}
+			Expect(i).To(Equal(keyCount))
+		})
+
+		It("must not run read operations in the background", func() {
+			statement := NewStatement(ns, set)
+			_, err := client.ExecuteOperations(nil, statement, GetOp())
+			Expect(err).To(HaveOccurred())
+
+			_, err = client.ExecuteOperations(nil, statement)
+			Expect(err).To(HaveOccurred())
+
+			// the UDF of the statement is neither run nor replaced
+			statement.SetAggregateFunction("udf1", "testFunc1", nil, false)
+			_, err = client.ExecuteOperations(nil, statement, PutOp(NewBin("bgbin", "bgvalue")))
+			Expect(err).To(HaveOccurred())
+		})
+
 		It("must run a DeleteUDF on a range of records", func() {
 			idxTask, err := client.CreateIndex(wpolicy, ns, set, set+bin1.Name, bin1.Name, NUMERIC)
 			Expect(<-idxTask.OnComplete()).ToNot(HaveOccurred())
@@ -24,6 +24,9 @@ type unpacker struct {
 	buffer []byte
 	offset int
 	length int
+
+	// return the maps as []MapPair, in their packed order
+	orderedMaps bool
 }
 
 func newUnpacker(buffer []byte, offset int, length int) *unpacker {
@@ -76,25 +79,94 @@ func (upckr *unpacker) UnpackMap() (map[interface{}]interface{}, error) {
 		return nil, nil
 	}
 
+	count, ok := upckr.unpackMapHeader()
+	if !ok {
+		return make(map[interface{}]interface{}), nil
+	}
+
+	count, err := upckr.skipMapOrder(count)
+	if err != nil {
+		return nil, err
+	}
+	return upckr.unpackMapEntries(count)
+}
+
+// UnpackOrderedList unpacks the list, and returns the maps nested in it as
+// []MapPair in their packed order.
+func (upckr *unpacker) UnpackOrderedList() ([]interface{}, error) {
+	upckr.orderedMaps = true
+	return upckr.UnpackList()
+}
+
+// UnpackOrderedMap unpacks the map, and the maps nested in it, as []MapPair
+// in their packed order.
+func (upckr *unpacker) UnpackOrderedMap() ([]MapPair, error) {
+	if upckr.length <= 0 {
+		return nil, nil
+	}
+
+	count, ok := upckr.unpackMapHeader()
+	if !ok {
+		return []MapPair{}, nil
+	}
+
+	count, err := upckr.skipMapOrder(count)
+	if err != nil {
+		return nil, err
+	}
+
+	upckr.orderedMaps = true
+	return upckr.unpackMapPairs(count)
+}
+
+// unpackMapHeader returns the number of entries of the map; false if the next object is not a map.
+func (upckr *unpacker) unpackMapHeader() (int, bool) {
 	theType := upckr.buffer[upckr.offset] & 0xff
 	upckr.offset++
-	var count int
 
 	if (theType & 0xf0) == 0x80 {
-		count = int(theType & 0x0f)
+		return int(theType & 0x0f), true
 	} else if theType == 0xde {
-		count = int(uint16(Buffer.BytesToInt16(upckr.buffer, upckr.offset)))
+		count := int(uint16(Buffer.BytesToInt16(upckr.buffer, upckr.offset)))
 		upckr.offset += 2
+		return count, true
 	} else if theType == 0xdf {
-		count = int(uint32(Buffer.BytesToInt32(upckr.buffer, upckr.offset)))
+		count := int(uint32(Buffer.BytesToInt32(upckr.buffer, upckr.offset)))
 		upckr.offset += 4
-	} else {
-		return make(map[interface{}]interface{}), nil
+		return count, true
 	}
-	return upckr.unpackMap(count)
+	return 0, false
 }
 
-func (upckr *unpacker) unpackMap(count int) (map[interface{}]interface{}, error) {
+func (upckr *unpacker) unpackMap(count int) (interface{}, error) {
+	count, err := upckr.skipMapOrder(count)
+	if err != nil {
+		return nil, err
+	}
+
+	if upckr.orderedMaps {
+		return upckr.unpackMapPairs(count)
+	}
+	return upckr.unpackMapEntries(count)
+}
+
+// skipMapOrder skips the order entry of ordered maps, and returns the number of the remaining entries.
+func (upckr *unpacker) skipMapOrder(count int) (int, error) {
+	// Ordered maps start with an entry which has an extension as its key
+	// and nil as its value. It describes the map's order; skip it.
+	if count > 0 && upckr.isExt() {
+		if err := upckr.skipExt(); err != nil {
+			return 0, err
+		}
+		if _, err := upckr.unpackObject(); err != nil {
+			return 0, err
+		}
+		count--
+	}
+	return count, nil
+}
+
+func (upckr *unpacker) unpackMapEntries(count int) (map[interface{}]interface{}, error) {
 	out := make(map[interface{}]interface{}, count)
 
 	for i := 0; i < count; i++ {
@@ -111,6 +183,67 @@ func (upckr *unpacker) unpackMap(count int) (map[interface{}]interface{}, error)
 	return out, nil
 }
 
+func (upckr *unpacker) unpackMapPairs(count int) ([]MapPair, error) {
+	out := make([]MapPair, count)
+
+	for i := range out {
+		key, err := upckr.unpackObject()
+		if err != nil {
+			return nil, err
+		}
+		val, err := upckr.unpackObject()
+		if err != nil {
+			return nil, err
+		}
+		out[i] = MapPair{Key: key, Value: val}
+	}
+	return out, nil
+}
+
+// isExt returns true if the next object is a MessagePack extension.
+func (upckr *unpacker) isExt() bool {
+	switch upckr.buffer[upckr.offset] & 0xff {
+	case 0xc7, 0xc8, 0xc9, 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return true
+	}
+	return false
+}
+
+// skipExt skips the MessagePack extension at the current offset.
+func (upckr *unpacker) skipExt() error {
+	theType := upckr.buffer[upckr.offset] & 0xff
+	upckr.offset++
+
+	var count int
+	switch theType {
+	case 0xd4:
+		count = 1
+	case 0xd5:
+		count = 2
+	case 0xd6:
+		count = 4
+	case 0xd7:
+		count = 8
+	case 0xd8:
+		count = 16
+	case 0xc7:
+		count = int(upckr.buffer[upckr.offset] & 0xff)
+		upckr.offset++
+	case 0xc8:
+		count = int(uint16(Buffer.BytesToInt16(upckr.buffer, upckr.offset)))
+		upckr.offset += 2
+	case 0xc9:
+		count = int(uint32(Buffer.BytesToInt32(upckr.buffer, upckr.offset)))
+		upckr.offset += 4
+	default:
+		return NewAerospikeError(SERIALIZE_ERROR)
+	}
+
+	// extension type, then the data
+	upckr.offset += 1 + count
+	return nil
+}
+
 func (upckr *unpacker) unpackBlob(count int) (interface{}, error) {
 	theType := upckr.buffer[upckr.offset] & 0xff
 	upckr.offset++
@@ -233,6 +366,11 @@ func (upckr *unpacker) unpackObject() (interface{}, error) {
 		upckr.offset += 4
 		return upckr.unpackMap(count)
 
+	case 0xc7, 0xc8, 0xc9, 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		// extensions carry no value for the client
+		upckr.offset--
+		return nil, upckr.skipExt()
+
 	default:
 		if (theType & 0xe0) == 0xa0 {
 			return upckr.unpackBlob(int(theType & 0x1f))
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/big"
 	"reflect"
 	"strconv"
 	"strings"
@@ -65,6 +66,49 @@ type AerospikeBlob interface {
 	EncodeBlob() ([]byte, error)
 }
 
+// ValueMarshaler is implemented by types which convert themselves to a
+// supported value type, e.g. a UUID to a string, or a protobuf message to
+// a []byte. It is honored by NewValue, in lists and maps, and in the fields
+// of the objects written by PutObject.
+type ValueMarshaler interface {
+	MarshalValue() (interface{}, error)
+}
+
+// ValueUnmarshaler is implemented by types which convert themselves back
+// from the value read from the server, in the fields of the objects read
+// by GetObject, ScanAllObjects and QueryObjects.
+// It is not honored anywhere else: the bins of a Record, and the values
+// of lists and maps, are returned as the built-in types of their particle
+// types, as values are unpacked without knowing the type to convert to.
+type ValueUnmarshaler interface {
+	UnmarshalValue(value interface{}) error
+}
+
+// CustomValue is implemented by application types which serialize themselves in the
+// wire protocol, to be used as bin values, keys, and in lists and maps, without being
+// converted to a supported type first. NewValue wraps them in a Value.
+// Values read from the server are returned as the built-in type of their particle type.
+type CustomValue interface {
+	// GetType returns the wire protocol particle type of the value, e.g. ParticleType.BLOB.
+	GetType() int
+
+	// EstimateSize returns the number of bytes Write will write.
+	EstimateSize() int
+
+	// Write serializes the value in the wire protocol into the buffer from the offset,
+	// and returns the number of bytes written.
+	Write(buffer []byte, offset int) (int, error)
+
+	// Pack serializes the value using MessagePack, in lists and maps.
+	Pack(packer Packer) error
+
+	// GetObject returns the value as an interface{}.
+	GetObject() interface{}
+
+	// String implements Stringer interface.
+	String() string
+}
+
 var sizeOfInt uintptr
 var sizeOfInt32 = uintptr(4)
 var sizeOfInt64 = uintptr(8)
@@ -79,6 +123,8 @@ func init() {
 
 // NewValue generates a new Value object based on the type.
 // If the type is not supported, NewValue will panic.
+// If the ValueMarshaler of the value returns an error, the returned Value fails
+// with the error when it is written, so the command using it returns the error.
 func NewValue(v interface{}) Value {
 	switch val := v.(type) {
 	case nil:
@@ -87,6 +133,21 @@ func NewValue(v interface{}) Value {
 		return NewIntegerValue(val)
 	case int64:
 		return NewLongValue(val)
+	case float64:
+		return NewFloatValue(val)
+	case float32:
+		return NewFloatValue(float64(val))
+	case bool:
+		return NewBoolValue(val)
+	case uint64:
+		if val <= math.MaxInt64 {
+			return NewLongValue(int64(val))
+		}
+		return NewBigIntValue(new(big.Int).SetUint64(val))
+	case *big.Int:
+		return NewBigIntValue(val)
+	case big.Int:
+		return NewBigIntValue(&val)
 	case string:
 		return NewStringValue(val)
 	case []Value:
@@ -109,12 +170,23 @@ func NewValue(v interface{}) Value {
 		if !Buffer.Arch64Bits || (val <= math.MaxInt64) {
 			return NewLongValue(int64(val))
 		}
+		return NewBigIntValue(new(big.Int).SetUint64(uint64(val)))
 	case []interface{}:
 		return NewListValue(val)
 	case map[interface{}]interface{}:
 		return NewMapValue(val)
+	case []MapPair:
+		return NewOrderedMapValue(val)
 	case Value:
 		return val
+	case CustomValue:
+		return &customValue{val}
+	case ValueMarshaler:
+		obj, err := val.MarshalValue()
+		if err != nil {
+			return &errorValue{err}
+		}
+		return NewValue(obj)
 	case AerospikeBlob:
 		return NewBlobValue(val)
 	}
@@ -140,8 +212,15 @@ func NewValue(v interface{}) Value {
 		return NewMapValue(amap)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 		return NewLongValue(reflect.ValueOf(v).Int())
-	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
-		return NewLongValue(int64(reflect.ValueOf(v).Uint()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() <= math.MaxInt64 {
+			return NewLongValue(int64(rv.Uint()))
+		}
+		return NewBigIntValue(new(big.Int).SetUint64(rv.Uint()))
+	case reflect.Bool:
+		return NewBoolValue(rv.Bool())
+	case reflect.Float32, reflect.Float64:
+		return NewFloatValue(rv.Float())
 	case reflect.String:
 		return NewStringValue(rv.String())
 	}
@@ -407,6 +486,200 @@ func (vl *LongValue) String() string {
 
 ///////////////////////////////////////////////////////////////////////////////
 
+// FloatValue encapsulates a float64 value.
+type FloatValue struct {
+	value float64
+}
+
+// NewFloatValue generates a FloatValue instance.
+func NewFloatValue(value float64) *FloatValue {
+	return &FloatValue{value: value}
+}
+
+func (vl *FloatValue) estimateSize() int {
+	return 8
+}
+
+func (vl *FloatValue) write(buffer []byte, offset int) (int, error) {
+	Buffer.Float64ToBytes(vl.value, buffer, offset)
+	return 8, nil
+}
+
+func (vl *FloatValue) pack(packer *packer) error {
+	packer.PackFloat64(vl.value)
+	return nil
+}
+
+// GetType returns wire protocol value type.
+func (vl *FloatValue) GetType() int {
+	return ParticleType.FLOAT
+}
+
+// GetObject returns original value as an interface{}.
+func (vl *FloatValue) GetObject() interface{} {
+	return vl.value
+}
+
+func (vl *FloatValue) reader() io.Reader {
+	return bytes.NewReader(Buffer.Float64ToBytes(vl.value, nil, 0))
+}
+
+// String implements Stringer interface.
+func (vl *FloatValue) String() string {
+	return strconv.FormatFloat(vl.value, 'G', -1, 64)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// BoolValue encapsulates a boolean value.
+// Boolean bins are only supported by Aerospike 5.6+ servers.
+type BoolValue struct {
+	value bool
+}
+
+// NewBoolValue generates a BoolValue instance.
+func NewBoolValue(value bool) *BoolValue {
+	return &BoolValue{value: value}
+}
+
+func (vl *BoolValue) estimateSize() int {
+	return 1
+}
+
+func (vl *BoolValue) write(buffer []byte, offset int) (int, error) {
+	if vl.value {
+		buffer[offset] = 1
+	} else {
+		buffer[offset] = 0
+	}
+	return 1, nil
+}
+
+func (vl *BoolValue) pack(packer *packer) error {
+	packer.PackBool(vl.value)
+	return nil
+}
+
+// GetType returns wire protocol value type.
+func (vl *BoolValue) GetType() int {
+	return ParticleType.BOOL
+}
+
+// GetObject returns original value as an interface{}.
+func (vl *BoolValue) GetObject() interface{} {
+	return vl.value
+}
+
+func (vl *BoolValue) reader() io.Reader {
+	buf := make([]byte, 1)
+	vl.write(buf, 0)
+	return bytes.NewReader(buf)
+}
+
+// String implements Stringer interface.
+func (vl *BoolValue) String() string {
+	return strconv.FormatBool(vl.value)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// BigIntValue encapsulates an arbitrary precision integer value.
+// Values in the int64 range are sent as integers. Larger values are sent
+// as blobs of their big-endian two's complement representation, which is
+// the encoding of Java's BigInteger.toByteArray(), and can be decoded by
+// the other clients. In lists and maps, values in the uint64 range are
+// packed as MessagePack unsigned integers.
+type BigIntValue struct {
+	value *big.Int
+}
+
+// NewBigIntValue generates a BigIntValue instance.
+func NewBigIntValue(value *big.Int) *BigIntValue {
+	return &BigIntValue{value: value}
+}
+
+func (vl *BigIntValue) estimateSize() int {
+	if vl.value.IsInt64() {
+		return 8
+	}
+	return len(bigIntToBytes(vl.value))
+}
+
+func (vl *BigIntValue) write(buffer []byte, offset int) (int, error) {
+	if vl.value.IsInt64() {
+		Buffer.Int64ToBytes(vl.value.Int64(), buffer, offset)
+		return 8, nil
+	}
+	return copy(buffer[offset:], bigIntToBytes(vl.value)), nil
+}
+
+func (vl *BigIntValue) pack(packer *packer) error {
+	switch {
+	case vl.value.IsInt64():
+		packer.PackALong(vl.value.Int64())
+	case vl.value.IsUint64():
+		packer.PackAULong(vl.value.Uint64())
+	default:
+		packer.PackBytes(bigIntToBytes(vl.value))
+	}
+	return nil
+}
+
+// GetType returns wire protocol value type.
+func (vl *BigIntValue) GetType() int {
+	if vl.value.IsInt64() {
+		return ParticleType.INTEGER
+	}
+	return ParticleType.BLOB
+}
+
+// GetObject returns original value as an interface{}.
+func (vl *BigIntValue) GetObject() interface{} {
+	return vl.value
+}
+
+func (vl *BigIntValue) reader() io.Reader {
+	buf := make([]byte, vl.estimateSize())
+	vl.write(buf, 0)
+	return bytes.NewReader(buf)
+}
+
+// String implements Stringer interface.
+func (vl *BigIntValue) String() string {
+	return vl.value.String()
+}
+
+// bigIntToBytes returns the big-endian two's complement representation
+// of the integer, in the minimum number of bytes.
+func bigIntToBytes(i *big.Int) []byte {
+	var n int
+	v := i
+	if i.Sign() >= 0 {
+		n = i.BitLen()/8 + 1
+	} else {
+		// -i-1 has the same magnitude in bits as i
+		n = new(big.Int).Not(i).BitLen()/8 + 1
+		v = new(big.Int).Add(i, new(big.Int).Lsh(big.NewInt(1), uint(n*8)))
+	}
+
+	b := make([]byte, n)
+	vb := v.Bytes()
+	copy(b[n-len(vb):], vb)
+	return b
+}
+
+// bytesToBigInt decodes the big-endian two's complement representation
+// of an integer.
+func bytesToBigInt(b []byte) *big.Int {
+	i := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		i.Sub(i, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	return i
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
 // ValueArray encapsulates an array of Value.
 // Supported by Aerospike 3 servers only.
 type ValueArray struct {
@@ -586,14 +859,95 @@ func (vl *MapValue) String() string {
 	return fmt.Sprintf("%v", vl.vmap)
 }
 
+// MapPair is a key/value pair of an ordered map.
+type MapPair struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// OrderedMapValue encapsulates a map as a slice of its key/value pairs,
+// which are packed in the given order.
+// Supported by Aerospike 3 servers only.
+type OrderedMapValue struct {
+	pairs []MapPair
+	order mapOrderType
+	bytes []byte
+}
+
+// NewOrderedMapValue generates an OrderedMapValue instance.
+// The pairs are packed in their order, which makes the digests of keys
+// containing maps deterministic, but the server stores an unordered map.
+// Use NewOrderedMapValueWithOrder to store a key ordered map.
+func NewOrderedMapValue(pairs []MapPair) *OrderedMapValue {
+	return NewOrderedMapValueWithOrder(pairs, MapOrder.UNORDERED)
+}
+
+// NewOrderedMapValueWithOrder generates an OrderedMapValue instance, which the
+// server stores as a map of the given order, like the maps created with a MapPolicy.
+// The pairs must already be sorted in that order.
+func NewOrderedMapValueWithOrder(pairs []MapPair, order mapOrderType) *OrderedMapValue {
+	res := &OrderedMapValue{
+		pairs: pairs,
+		order: order,
+	}
+
+	res.bytes, _ = packOrderedMap(pairs, order)
+
+	return res
+}
+
+func (vl *OrderedMapValue) estimateSize() int {
+	return len(vl.bytes)
+}
+
+func (vl *OrderedMapValue) write(buffer []byte, offset int) (int, error) {
+	return copy(buffer[offset:], vl.bytes), nil
+}
+
+func (vl *OrderedMapValue) pack(packer *packer) error {
+	_, err := packer.buffer.Write(vl.bytes)
+	return err
+}
+
+// GetType returns wire protocol value type.
+func (vl *OrderedMapValue) GetType() int {
+	return ParticleType.MAP
+}
+
+// GetObject returns original value as an interface{}.
+func (vl *OrderedMapValue) GetObject() interface{} {
+	return vl.pairs
+}
+
+func (vl *OrderedMapValue) reader() io.Reader {
+	return bytes.NewReader(vl.bytes)
+}
+
+// String implements Stringer interface.
+func (vl *OrderedMapValue) String() string {
+	return fmt.Sprintf("%v", vl.pairs)
+}
+
 //////////////////////////////////////////////////////////////////////////////
 
 func bytesToParticle(ptype int, buf []byte, offset int, length int) (interface{}, error) {
+	return decodeParticle(ptype, buf, offset, length, false)
+}
+
+// decodeParticle works like bytesToParticle, but returns the maps as []MapPair
+// in their stored order if orderedMaps is set.
+func decodeParticle(ptype int, buf []byte, offset int, length int, orderedMaps bool) (interface{}, error) {
 
 	switch ptype {
 	case ParticleType.INTEGER:
 		return Buffer.BytesToNumber(buf, offset, length), nil
 
+	case ParticleType.FLOAT:
+		return Buffer.BytesToFloat64(buf, offset), nil
+
+	case ParticleType.BOOL:
+		return buf[offset] != 0, nil
+
 	case ParticleType.STRING:
 		return string(buf[offset : offset+length]), nil
 
@@ -603,9 +957,15 @@ func bytesToParticle(ptype int, buf []byte, offset int, length int) (interface{}
 		return newObj, nil
 
 	case ParticleType.LIST:
+		if orderedMaps {
+			return newUnpacker(buf, offset, length).UnpackOrderedList()
+		}
 		return newUnpacker(buf, offset, length).UnpackList()
 
 	case ParticleType.MAP:
+		if orderedMaps {
+			return newUnpacker(buf, offset, length).UnpackOrderedMap()
+		}
 		return newUnpacker(buf, offset, length).UnpackMap()
 
 	}
@@ -630,3 +990,74 @@ func bytesToKeyValue(pType int, buf []byte, offset int, len int) (Value, error)
 		return nil, nil
 	}
 }
+
+// customValue adapts a CustomValue to the Value interface.
+type customValue struct {
+	CustomValue
+}
+
+func (vl *customValue) estimateSize() int {
+	return vl.EstimateSize()
+}
+
+func (vl *customValue) write(buffer []byte, offset int) (int, error) {
+	return vl.Write(buffer, offset)
+}
+
+func (vl *customValue) pack(packer *packer) error {
+	return vl.Pack(packer)
+}
+
+func (vl *customValue) reader() io.Reader {
+	buf := make([]byte, vl.EstimateSize())
+	if _, err := vl.Write(buf, 0); err != nil {
+		return errorReader{err}
+	}
+	return bytes.NewReader(buf)
+}
+
+// errorValue is the value of a ValueMarshaler which failed to marshal itself.
+// It returns the error when it is written.
+type errorValue struct {
+	err error
+}
+
+func (vl *errorValue) estimateSize() int {
+	return 0
+}
+
+func (vl *errorValue) write(buffer []byte, offset int) (int, error) {
+	return 0, vl.err
+}
+
+func (vl *errorValue) pack(packer *packer) error {
+	return vl.err
+}
+
+// GetType returns wire protocol value type.
+func (vl *errorValue) GetType() int {
+	return ParticleType.NULL
+}
+
+// GetObject returns the marshaling error.
+func (vl *errorValue) GetObject() interface{} {
+	return vl.err
+}
+
+func (vl *errorValue) reader() io.Reader {
+	return errorReader{vl.err}
+}
+
+// String implements Stringer interface.
+func (vl *errorValue) String() string {
+	return vl.err.Error()
+}
+
+// errorReader returns the error of a value which failed to write itself.
+type errorReader struct {
+	err error
+}
+
+func (r errorReader) Read(p []byte) (int, error) {
+	return 0, r.err
+}
@@ -15,7 +15,10 @@
 package aerospike
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"math/big"
 	"reflect"
 
 	. "github.com/onsi/ginkgo"
@@ -34,6 +37,43 @@ func (b *testBLOB) EncodeBlob() ([]byte, error) {
 	return append([]byte(b.name)), nil
 }
 
+// testIP is a custom value type, sent as a 4 byte BLOB.
+type testIP [4]byte
+
+func (ip testIP) GetType() int {
+	return ParticleType.BLOB
+}
+
+func (ip testIP) EstimateSize() int {
+	return len(ip)
+}
+
+func (ip testIP) Write(buffer []byte, offset int) (int, error) {
+	return copy(buffer[offset:], ip[:]), nil
+}
+
+func (ip testIP) Pack(packer Packer) error {
+	packer.PackBytes(ip[:])
+	return nil
+}
+
+func (ip testIP) GetObject() interface{} {
+	return ip[:]
+}
+
+func (ip testIP) String() string {
+	return fmt.Sprintf("%d.%d.%d.%d", ip[0], ip[1], ip[2], ip[3])
+}
+
+// testInvalidIP is a custom value which fails to write itself.
+type testInvalidIP struct {
+	testIP
+}
+
+func (ip testInvalidIP) Write(buffer []byte, offset int) (int, error) {
+	return 0, errors.New("invalid IP")
+}
+
 func isValidIntegerValue(i int, v Value) bool {
 	Expect(reflect.TypeOf(v)).To(Equal(reflect.TypeOf(NewIntegerValue(0))))
 	Expect(v.GetObject()).To(Equal(i))
@@ -52,6 +92,15 @@ func isValidLongValue(i int64, v Value) bool {
 	return true
 }
 
+func isValidFloatValue(f float64, v Value) bool {
+	Expect(reflect.TypeOf(v)).To(Equal(reflect.TypeOf(NewFloatValue(0))))
+	Expect(v.GetObject().(float64)).To(Equal(f))
+	Expect(v.estimateSize()).To(Equal(8))
+	Expect(v.GetType()).To(Equal(ParticleType.FLOAT))
+
+	return true
+}
+
 var _ = Describe("Value Test", func() {
 
 	Context("NullValue", func() {
@@ -194,5 +243,197 @@ var _ = Describe("Value Test", func() {
 			isValidLongValue(i, v)
 		})
 
+		It("should create a valid FloatValue from float32 and float64", func() {
+			f := float64(math.MaxFloat64)
+			v := NewValue(f)
+			isValidFloatValue(f, v)
+
+			f = math.SmallestNonzeroFloat64
+			v = NewValue(f)
+			isValidFloatValue(f, v)
+
+			v = NewValue(float32(1.5))
+			isValidFloatValue(1.5, v)
+		})
+
+		It("should write and read back a FloatValue without loss", func() {
+			f := -math.Pi
+			v := NewFloatValue(f)
+
+			buf := make([]byte, v.estimateSize())
+			n, err := v.write(buf, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			obj, err := bytesToParticle(v.GetType(), buf, 0, n)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj).To(Equal(f))
+		})
+
+		It("should pack FloatValues in lists and maps", func() {
+			l := NewValue([]interface{}{1.5, float32(2.5)})
+			Expect(l.GetType()).To(Equal(ParticleType.LIST))
+
+			packer := newPacker()
+			Expect(l.pack(packer)).ToNot(HaveOccurred())
+
+			obj, err := newUnpacker(packer.buffer.Bytes(), 0, packer.buffer.Len()).UnpackList()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj).To(Equal([]interface{}{1.5, float32(2.5)}))
+		})
+
+		It("should create a valid BigIntValue for uint64 values above int64", func() {
+			v := NewValue(uint64(math.MaxUint64))
+			Expect(v.GetObject()).To(Equal(new(big.Int).SetUint64(math.MaxUint64)))
+			Expect(v.GetType()).To(Equal(ParticleType.BLOB))
+
+			isValidLongValue(math.MaxInt64, NewValue(uint64(math.MaxInt64)))
+		})
+
+		It("should send BigIntValues in the int64 range as integers", func() {
+			v := NewValue(big.NewInt(-42))
+			Expect(v.GetType()).To(Equal(ParticleType.INTEGER))
+			Expect(v.estimateSize()).To(Equal(8))
+
+			buf := make([]byte, v.estimateSize())
+			_, err := v.write(buf, 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(BytesToNumber(buf, 0, 8)).To(Equal(-42))
+		})
+
+		It("should encode big integers in two's complement", func() {
+			for _, s := range []string{"0", "127", "128", "-128", "-129", "255", "-256", "18446744073709551616", "-170141183460469231731687303715884105729"} {
+				i, _ := new(big.Int).SetString(s, 10)
+				Expect(bytesToBigInt(bigIntToBytes(i)).Cmp(i)).To(Equal(0), s)
+			}
+
+			Expect(bigIntToBytes(big.NewInt(128))).To(Equal([]byte{0x00, 0x80}))
+			Expect(bigIntToBytes(big.NewInt(-128))).To(Equal([]byte{0x80}))
+			Expect(bigIntToBytes(big.NewInt(-129))).To(Equal([]byte{0xff, 0x7f}))
+		})
+
+		It("should pack BigIntValues in lists", func() {
+			huge, _ := new(big.Int).SetString("18446744073709551616", 10)
+			l := NewValue([]interface{}{big.NewInt(1), new(big.Int).SetUint64(math.MaxUint64), huge})
+
+			packer := newPacker()
+			Expect(l.pack(packer)).ToNot(HaveOccurred())
+
+			obj, err := newUnpacker(packer.buffer.Bytes(), 0, packer.buffer.Len()).UnpackList()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj).To(Equal([]interface{}{1, uint64(math.MaxUint64), bigIntToBytes(huge)}))
+		})
+
 	}) // numeric values context
+
+	Context("BoolValues", func() {
+		It("should create a valid BoolValue", func() {
+			v := NewValue(true)
+			Expect(v.GetObject()).To(Equal(true))
+			Expect(v.estimateSize()).To(Equal(1))
+			Expect(v.GetType()).To(Equal(ParticleType.BOOL))
+
+			buf := make([]byte, 1)
+			_, err := v.write(buf, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			obj, err := bytesToParticle(v.GetType(), buf, 0, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj).To(Equal(true))
+
+			obj, err = bytesToParticle(ParticleType.BOOL, []byte{0}, 0, 1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj).To(Equal(false))
+		})
+	})
+
+	Context("CustomValues", func() {
+		ip := testIP{10, 0, 0, 1}
+
+		It("should write a CustomValue in the wire protocol", func() {
+			v := NewValue(ip)
+			Expect(v.GetType()).To(Equal(ParticleType.BLOB))
+			Expect(v.estimateSize()).To(Equal(4))
+			Expect(v.String()).To(Equal("10.0.0.1"))
+
+			buf := make([]byte, v.estimateSize())
+			n, err := v.write(buf, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			obj, err := bytesToParticle(v.GetType(), buf, 0, n)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj).To(Equal([]byte{10, 0, 0, 1}))
+		})
+
+		It("should return the error of a CustomValue used as a key", func() {
+			key, err := NewKey("test", "demo", ip)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(key.Digest()).To(HaveLen(20))
+
+			_, err = NewKey("test", "demo", testInvalidIP{ip})
+			Expect(err).To(MatchError("invalid IP"))
+		})
+
+		It("should pack CustomValues in lists and maps", func() {
+			packer := newPacker()
+			Expect(packer.PackObject([]interface{}{ip, map[interface{}]interface{}{"ip": ip}})).ToNot(HaveOccurred())
+
+			obj, err := newUnpacker(packer.buffer.Bytes(), 0, packer.buffer.Len()).UnpackList()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj).To(Equal([]interface{}{[]byte{10, 0, 0, 1}, map[interface{}]interface{}{"ip": []byte{10, 0, 0, 1}}}))
+		})
+
+		It("should compute the digest of CustomValue keys", func() {
+			key, err := NewKey("test", "set", ip)
+			Expect(err).ToNot(HaveOccurred())
+
+			expected, err := NewKey("test", "set", []byte{10, 0, 0, 1})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(key.Digest()).To(Equal(expected.Digest()))
+		})
+
+		It("should write CustomValue fields of objects as is", func() {
+			obj := &struct {
+				IP  testIP
+				IPP *testIP
+			}{IP: ip}
+
+			bins, err := marshal(&obj, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(bins).To(HaveLen(1))
+			Expect(bins[0].Value.String()).To(Equal("10.0.0.1"))
+		})
+	})
+
+	Context("OrderedMapValues", func() {
+		It("should create an OrderedMapValue, and decode it in order", func() {
+			pairs := []MapPair{{"z", 1}, {"a", 2}}
+			v := NewValue(pairs)
+			Expect(v).To(BeAssignableToTypeOf(&OrderedMapValue{}))
+			Expect(v.GetType()).To(Equal(ParticleType.MAP))
+			Expect(v.GetObject()).To(Equal(pairs))
+
+			buf := make([]byte, v.estimateSize())
+			n, err := v.write(buf, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			obj, err := decodeParticle(v.GetType(), buf, 0, n, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj).To(Equal(pairs))
+
+			obj, err = bytesToParticle(v.GetType(), buf, 0, n)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj).To(Equal(map[interface{}]interface{}{"z": 1, "a": 2}))
+		})
+
+		It("should pack lists containing ordered maps deterministically", func() {
+			list := func() Value {
+				return NewValue([]interface{}{[]MapPair{{"c", 3}, {"b", 2}, {"a", 1}, {"d", 4}}})
+			}
+
+			bytes := list().(*ListValue).bytes
+			for i := 0; i < 10; i++ {
+				Expect(list().(*ListValue).bytes).To(Equal(bytes))
+			}
+		})
+	})
 })
@@ -49,6 +49,11 @@ type WritePolicy struct {
 	// Send user defined key in addition to hash digest on a record put.
 	// The default is to not send the user defined key.
 	SendKey bool
+
+	// UseBoolBin determines whether PutObject stores the bool fields of structs as
+	// server booleans, which require Aerospike 5.6+ servers.
+	// The default is to store them as integers 1 and 0, which all servers support.
+	UseBoolBin bool
 }
 
 // NewWritePolicy initializes a new WritePolicy instance with default parameters.